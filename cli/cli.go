@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/AR1011/slog"
 	"github.com/nonhumantrades/flowdb-go/client"
@@ -15,9 +17,28 @@ import (
 
 const defaultServerAddr = "localhost:7777"
 
+// how long dial waits for the server before going on without it
+const dialTimeout = 3 * time.Second
+
 type Opts struct {
 	credentialsPath string
 	serverAddr      string
+	input           io.Reader
+}
+
+func (o *Opts) WithCredentialsPath(path string) *Opts {
+	o.credentialsPath = path
+	return o
+}
+
+func (o *Opts) WithServerAddr(addr string) *Opts {
+	o.serverAddr = addr
+	return o
+}
+
+func (o *Opts) WithInput(r io.Reader) *Opts {
+	o.input = r
+	return o
 }
 
 func (o *Opts) FillDefaults() *Opts {
@@ -35,6 +56,10 @@ func (o *Opts) FillDefaults() *Opts {
 		o.serverAddr = defaultServerAddr
 	}
 
+	if o.input == nil {
+		o.input = os.Stdin
+	}
+
 	return o
 }
 
@@ -43,9 +68,26 @@ type S3Profile struct {
 	Creds types.S3Credentials `json:"creds"`
 }
 
+type TLSSettings struct {
+	CAFile     string `json:"ca_file,omitempty"`
+	CertFile   string `json:"cert_file,omitempty"`
+	KeyFile    string `json:"key_file,omitempty"`
+	ServerName string `json:"server_name,omitempty"`
+	Insecure   bool   `json:"insecure,omitempty"`
+}
+
+// ServerToken authenticates calls to one server.
+type ServerToken struct {
+	Token  string `json:"token"`
+	APIKey bool   `json:"api_key,omitempty"`
+}
+
 type storedState struct {
-	S3Profiles []S3Profile `json:"s3_profiles,omitempty"`
-	ServerAddr string      `json:"server_addr,omitempty"`
+	S3Profiles []S3Profile  `json:"s3_profiles,omitempty"`
+	ServerAddr string       `json:"server_addr,omitempty"`
+	TLS        *TLSSettings `json:"tls,omitempty"`
+	// by server address
+	Tokens map[string]ServerToken `json:"tokens,omitempty"`
 }
 
 type Cli struct {
@@ -71,7 +113,7 @@ func New(opts *Opts) *Cli {
 
 	c := &Cli{
 		opts:   *opts,
-		reader: bufio.NewReader(os.Stdin),
+		reader: bufio.NewReader(opts.input),
 		parser: NewParser(),
 		ctx:    ctx,
 		cancel: cancel,
@@ -81,9 +123,7 @@ func New(opts *Opts) *Cli {
 		slog.Warn("cli.init: error during init()", "error", err)
 	}
 
-	cl, err := client.Dial(ctx, client.Config{
-		Address: c.opts.serverAddr,
-	})
+	cl, err := c.dial(c.opts.serverAddr, c.state.TLS)
 	if err != nil {
 		slog.Warn("cli.init: error during client.New()", "error", err)
 	} else {
@@ -115,6 +155,44 @@ func (c *Cli) init() error {
 	return nil
 }
 
+// dial creates a client for addr, using TLS when settings are given and
+// the token stored for addr, if any. When addr can't be reached within
+// dialTimeout it warns and returns a client connecting on first use, so
+// config can still be changed while the server is down.
+func (c *Cli) dial(addr string, settings *TLSSettings) (*client.Client, error) {
+	cfg := client.Config{Address: addr}
+	if t, ok := c.state.Tokens[addr]; ok {
+		if t.APIKey {
+			cfg.Credentials = client.APIKey(t.Token)
+		} else {
+			cfg.Credentials = client.BearerToken(t.Token)
+		}
+	}
+	if settings != nil {
+		tc, err := client.NewTLSConfig(client.TLSFiles{
+			CAFile:             settings.CAFile,
+			CertFile:           settings.CertFile,
+			KeyFile:            settings.KeyFile,
+			ServerName:         settings.ServerName,
+			InsecureSkipVerify: settings.Insecure,
+		})
+		if err != nil {
+			return nil, err
+		}
+		cfg.TLSConfig = tc
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, dialTimeout)
+	defer cancel()
+	cl, err := client.Dial(ctx, cfg)
+	if !errors.Is(err, client.ErrNoConnection) {
+		return cl, err
+	}
+	fmt.Printf("warning: can't reach %s: %v\n", addr, err)
+	cfg.Lazy = true
+	return client.Dial(c.ctx, cfg)
+}
+
 func isEmptyS3(c types.S3Credentials) bool {
 	return c.Bucket == "" && c.Url == "" && c.AccessKey == "" && c.SecretKey == "" && c.Region == ""
 }
@@ -155,6 +233,10 @@ func (c *Cli) registerCommands() {
 	c.parser.Register("config show", func() any { return &ShowConfig{} })
 	c.parser.Register("config set", func() any { return &SetConfig{} })
 	c.parser.Register("config reset", func() any { return &ResetConfig{} })
+	c.parser.Register("config tls", func() any { return &SetTLS{} })
+	c.parser.Register("config tls off", func() any { return &DisableTLS{} })
+	c.parser.Register("config token", func() any { return &SetToken{} })
+	c.parser.Register("config token clear", func() any { return &ClearToken{} })
 }
 
 func (c *Cli) Loop() {
@@ -231,6 +313,14 @@ func (c *Cli) Loop() {
 			c.handleConfigSet(cmd)
 		case *ResetConfig:
 			c.handleConfigReset(cmd)
+		case *SetTLS:
+			c.handleConfigTLS(cmd)
+		case *DisableTLS:
+			c.handleConfigTLSOff(cmd)
+		case *SetToken:
+			c.handleConfigToken(cmd)
+		case *ClearToken:
+			c.handleConfigTokenClear(cmd)
 		default:
 			fmt.Printf("no handler for type %T\n", cmd)
 		}
@@ -289,6 +379,12 @@ func (c *Cli) handleHelp() {
 	fmt.Println("  config show           Show current server address")
 	fmt.Println("  config set [addr=<a>] Set server address and recreate client")
 	fmt.Println("  config reset          Reset server address to default")
+	fmt.Println("  config tls [ca=<f>] [cert=<f>] [key=<f>]")
+	fmt.Println("                        Connect over TLS with the given PEM files")
+	fmt.Println("  config tls off        Connect without TLS")
+	fmt.Println("  config token [token=<t>] [api_key=true]")
+	fmt.Println("                        Authenticate to the current server")
+	fmt.Println("  config token clear    Forget the token of the current server")
 	fmt.Println()
 	fmt.Println("For more details on a group of commands:")
 	fmt.Println("  s3 help               S3-specific help")
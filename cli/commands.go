@@ -58,3 +58,20 @@ type ResetConfig struct{}
 type SetConfig struct {
 	Addr string `cli:"addr"`
 }
+
+type SetTLS struct {
+	CA         string `cli:"ca"`
+	Cert       string `cli:"cert"`
+	Key        string `cli:"key"`
+	ServerName string `cli:"server_name"`
+	Insecure   bool   `cli:"insecure"`
+}
+
+type DisableTLS struct{}
+
+type SetToken struct {
+	Token  string `cli:"token"`
+	APIKey bool   `cli:"api_key"`
+}
+
+type ClearToken struct{}
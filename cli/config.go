@@ -3,8 +3,6 @@ package cli
 import (
 	"fmt"
 	"strings"
-
-	"github.com/nonhumantrades/flowdb-go/client"
 )
 
 func (c *Cli) handleConfigHelp(_ *ConfigHelp) {
@@ -20,15 +18,64 @@ func (c *Cli) handleConfigHelp(_ *ConfigHelp) {
 	fmt.Println("      Reset the server address back to the built-in default:")
 	fmt.Printf("      %s\n", defaultServerAddr)
 	fmt.Println()
+	fmt.Println("  config tls [ca=<file>] [cert=<file>] [key=<file>] [server_name=<name>] [insecure=true]")
+	fmt.Println("      Connect over TLS and recreate the client. ca verifies the server")
+	fmt.Println("      (system roots if omitted); cert and key enable mutual TLS.")
+	fmt.Println("      Certificate files are re-read when they change.")
+	fmt.Println()
+	fmt.Println("  config tls off")
+	fmt.Println("      Connect without TLS and recreate the client.")
+	fmt.Println()
+	fmt.Println("  config token [token=<token>] [api_key=true]")
+	fmt.Println("      Store a token for the current server address and recreate the")
+	fmt.Println("      client. It is sent as a bearer token, or as an API key with")
+	fmt.Println("      api_key=true. If token is omitted, you will be prompted for it.")
+	fmt.Println()
+	fmt.Println("  config token clear")
+	fmt.Println("      Forget the token of the current server address.")
+	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  config show")
 	fmt.Println("  config set addr=127.0.0.1:7777")
+	fmt.Println("  config set addr=unix:///run/flowdb.sock")
+	fmt.Println("  config tls ca=ca.pem cert=client.pem key=client-key.pem")
+	fmt.Println("  config token api_key=true")
 	fmt.Println("  config reset")
 }
 
 func (c *Cli) handleConfigShow(_ *ShowConfig) {
 	fmt.Println("Current config:")
 	fmt.Printf("  server address: %s\n", c.opts.serverAddr)
+
+	if tok, ok := c.state.Tokens[c.opts.serverAddr]; ok {
+		kind := "bearer token"
+		if tok.APIKey {
+			kind = "api key"
+		}
+		fmt.Printf("  %s: %s\n", kind, maskKey(tok.Token))
+	} else {
+		fmt.Println("  token: none")
+	}
+
+	t := c.state.TLS
+	if t == nil {
+		fmt.Println("  tls: off")
+		return
+	}
+	fmt.Println("  tls: on")
+	if t.CAFile != "" {
+		fmt.Printf("    ca file: %s\n", t.CAFile)
+	}
+	if t.CertFile != "" {
+		fmt.Printf("    cert file: %s\n", t.CertFile)
+		fmt.Printf("    key file: %s\n", t.KeyFile)
+	}
+	if t.ServerName != "" {
+		fmt.Printf("    server name: %s\n", t.ServerName)
+	}
+	if t.Insecure {
+		fmt.Println("    insecure: server certificate not verified")
+	}
 }
 
 func (c *Cli) handleConfigSet(cmd *SetConfig) {
@@ -54,9 +101,7 @@ func (c *Cli) handleConfigSet(cmd *SetConfig) {
 		return
 	}
 
-	newClient, err := client.Dial(c.ctx, client.Config{
-		Address: newAddr,
-	})
+	newClient, err := c.dial(newAddr, c.state.TLS)
 	if err != nil {
 		fmt.Printf("failed to create client with new address: %v\n", err)
 		return
@@ -83,9 +128,7 @@ func (c *Cli) handleConfigReset(_ *ResetConfig) {
 		return
 	}
 
-	newClient, err := client.Dial(c.ctx, client.Config{
-		Address: defaultServerAddr,
-	})
+	newClient, err := c.dial(defaultServerAddr, c.state.TLS)
 	if err != nil {
 		fmt.Printf("failed to reset client config: %v\n", err)
 		return
@@ -105,3 +148,132 @@ func (c *Cli) handleConfigReset(_ *ResetConfig) {
 
 	fmt.Printf("server address reset to default (%s)\n", defaultServerAddr)
 }
+
+func (c *Cli) handleConfigTLS(cmd *SetTLS) {
+	settings := &TLSSettings{
+		CAFile:     strings.TrimSpace(cmd.CA),
+		CertFile:   strings.TrimSpace(cmd.Cert),
+		KeyFile:    strings.TrimSpace(cmd.Key),
+		ServerName: strings.TrimSpace(cmd.ServerName),
+		Insecure:   cmd.Insecure,
+	}
+
+	newClient, err := c.dial(c.opts.serverAddr, settings)
+	if err != nil {
+		fmt.Printf("failed to create client with tls: %v\n", err)
+		return
+	}
+
+	old := c.client
+	c.client = newClient
+	c.state.TLS = settings
+
+	if old != nil {
+		_ = old.Close()
+	}
+
+	if err := c.saveState(); err != nil {
+		fmt.Printf("warning: tls enabled but failed to save: %v\n", err)
+	}
+
+	fmt.Println("tls enabled")
+}
+
+func (c *Cli) handleConfigTLSOff(_ *DisableTLS) {
+	if c.state.TLS == nil {
+		fmt.Println("tls already off")
+		return
+	}
+
+	newClient, err := c.dial(c.opts.serverAddr, nil)
+	if err != nil {
+		fmt.Printf("failed to create client without tls: %v\n", err)
+		return
+	}
+
+	old := c.client
+	c.client = newClient
+	c.state.TLS = nil
+
+	if old != nil {
+		_ = old.Close()
+	}
+
+	if err := c.saveState(); err != nil {
+		fmt.Printf("warning: tls disabled but failed to save: %v\n", err)
+	}
+
+	fmt.Println("tls disabled")
+}
+
+func (c *Cli) handleConfigToken(cmd *SetToken) {
+	token := strings.TrimSpace(cmd.Token)
+
+	var err error
+	if token == "" {
+		token, err = c.promptNonEmpty("Token")
+		if err != nil {
+			fmt.Printf("aborted: %v\n", err)
+			return
+		}
+	}
+
+	prev, had := c.state.Tokens[c.opts.serverAddr]
+	if c.state.Tokens == nil {
+		c.state.Tokens = make(map[string]ServerToken)
+	}
+	c.state.Tokens[c.opts.serverAddr] = ServerToken{Token: token, APIKey: cmd.APIKey}
+
+	newClient, err := c.dial(c.opts.serverAddr, c.state.TLS)
+	if err != nil {
+		if had {
+			c.state.Tokens[c.opts.serverAddr] = prev
+		} else {
+			delete(c.state.Tokens, c.opts.serverAddr)
+		}
+		fmt.Printf("failed to create client with token: %v\n", err)
+		return
+	}
+
+	old := c.client
+	c.client = newClient
+
+	if old != nil {
+		_ = old.Close()
+	}
+
+	if err := c.saveState(); err != nil {
+		fmt.Printf("warning: token set but failed to save: %v\n", err)
+	}
+
+	fmt.Printf("token set for %s\n", c.opts.serverAddr)
+}
+
+func (c *Cli) handleConfigTokenClear(_ *ClearToken) {
+	prev, ok := c.state.Tokens[c.opts.serverAddr]
+	if !ok {
+		fmt.Println("no token set for", c.opts.serverAddr)
+		return
+	}
+
+	delete(c.state.Tokens, c.opts.serverAddr)
+	newClient, err := c.dial(c.opts.serverAddr, c.state.TLS)
+	if err != nil {
+		c.state.Tokens[c.opts.serverAddr] = prev
+		fmt.Printf("failed to create client without token: %v\n", err)
+		return
+	}
+
+	old := c.client
+	c.client = newClient
+
+	if old != nil {
+		_ = old.Close()
+	}
+
+	if err := c.saveState(); err != nil {
+		fmt.Printf("warning: token cleared but failed to save: %v\n", err)
+	}
+
+	fmt.Printf("token cleared for %s\n", c.opts.serverAddr)
+}
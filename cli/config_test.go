@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nonhumantrades/flowdb-go/memserver"
+)
+
+// nothing listens on port 1
+const downAddr = "127.0.0.1:1"
+
+func TestConfigWithServerDown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flowdbcli.json")
+	c := New(new(Opts).WithCredentialsPath(path).WithServerAddr(downAddr).WithInput(strings.NewReader("")))
+	if c.client == nil {
+		t.Fatal("no client while the server is down")
+	}
+
+	c.handleConfigSet(&SetConfig{Addr: "127.0.0.1:2"})
+	c.handleConfigToken(&SetToken{Token: "secret"})
+	c.handleConfigTLS(&SetTLS{Insecure: true})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var st storedState
+	if err := json.Unmarshal(data, &st); err != nil {
+		t.Fatal(err)
+	}
+	if st.ServerAddr != "127.0.0.1:2" {
+		t.Fatalf("saved address %q", st.ServerAddr)
+	}
+	if st.Tokens["127.0.0.1:2"].Token != "secret" {
+		t.Fatalf("saved tokens %+v", st.Tokens)
+	}
+	if st.TLS == nil || !st.TLS.Insecure {
+		t.Fatalf("saved tls %+v", st.TLS)
+	}
+
+	c.handleConfigTokenClear(&ClearToken{})
+	c.handleConfigTLSOff(&DisableTLS{})
+	if _, ok := c.state.Tokens["127.0.0.1:2"]; ok || c.state.TLS != nil {
+		t.Fatalf("state after clearing %+v", c.state)
+	}
+}
+
+func TestDialConnects(t *testing.T) {
+	s := memserver.New()
+	defer s.Close()
+	addr, err := s.Listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "flowdbcli.json")
+	c := New(new(Opts).WithCredentialsPath(path).WithServerAddr(addr).WithInput(strings.NewReader("")))
+	if c.client == nil {
+		t.Fatal("no client")
+	}
+	defer c.client.Close()
+	// connected by New rather than on first use
+	if eps := c.client.Endpoints(); eps[0].Conns == 0 {
+		t.Fatalf("endpoints %+v", eps)
+	}
+}
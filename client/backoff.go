@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffPolicy decides how long to wait before retry attempt n (1-based),
+// given the time elapsed since the call started. Returning false stops
+// retrying.
+type BackoffPolicy interface {
+	Next(attempt int, elapsed time.Duration) (time.Duration, bool)
+}
+
+type ExponentialBackoff struct {
+	// first wait (default = Config.ReconnectInterval)
+	Initial time.Duration
+	// upper bound for a single wait (default = 30 s)
+	Max time.Duration
+	// growth per attempt (default = 2)
+	Multiplier float64
+	// fraction of each wait that is randomized, 0..1 (default = 0.2,
+	// < 0 = none)
+	Jitter float64
+	// stop retrying once this much time has passed (0 = no limit)
+	MaxElapsed time.Duration
+}
+
+func (b *ExponentialBackoff) Next(attempt int, elapsed time.Duration) (time.Duration, bool) {
+	d := float64(b.Initial)
+	for i := 1; i < attempt && d < float64(b.Max); i++ {
+		d *= b.Multiplier
+	}
+	d = min(d, float64(b.Max))
+
+	if b.Jitter > 0 {
+		d -= d * b.Jitter * rand.Float64()
+	}
+
+	wait := time.Duration(d)
+	if b.MaxElapsed > 0 && elapsed+wait > b.MaxElapsed {
+		return 0, false
+	}
+	return wait, true
+}
+
+func (b *ExponentialBackoff) applyDefaults(initial time.Duration) {
+	if b.Initial <= 0 {
+		b.Initial = initial
+	}
+	if b.Max <= 0 {
+		b.Max = 30 * time.Second
+	}
+	if b.Max < b.Initial {
+		b.Max = b.Initial
+	}
+	if b.Multiplier < 1 {
+		b.Multiplier = 2
+	}
+	if b.Jitter == 0 || b.Jitter > 1 {
+		b.Jitter = 0.2
+	}
+}
+
+// retrier tracks one logical call across its attempts.
+type retrier struct {
+	policy  BackoffPolicy
+	max     int
+	start   time.Time
+	attempt int
+	// repeat the current attempt without waiting; see again
+	repeat bool
+}
+
+func (c *Client) newRetrier(o *callOptions) *retrier {
+	return &retrier{
+		policy: c.cfg.Backoff,
+		max:    o.maxRetries,
+		start:  time.Now(),
+	}
+}
+
+// next reports whether another attempt may be made, waiting out the backoff
+// first when this is not the first attempt. It returns ctx's error as soon
+// as ctx is done.
+func (r *retrier) next(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	if r.repeat {
+		r.repeat = false
+		return true, nil
+	}
+
+	r.attempt++
+	if r.attempt == 1 {
+		return true, nil
+	}
+	if r.attempt > r.max {
+		return false, nil
+	}
+
+	wait, ok := r.policy.Next(r.attempt-1, time.Since(r.start))
+	if !ok {
+		return false, nil
+	}
+	if err := sleepCtx(ctx, wait); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// again makes the next call to next allow the current attempt once more,
+// without a backoff and without counting it as a retry.
+func (r *retrier) again() {
+	r.repeat = true
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
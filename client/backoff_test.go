@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	b := &ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second, Jitter: -1}
+	b.applyDefaults(time.Second)
+
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, w := range want {
+		d, ok := b.Next(i+1, 0)
+		if !ok || d != w*time.Millisecond {
+			t.Fatalf("attempt %d: got %v %v, want %v", i+1, d, ok, w*time.Millisecond)
+		}
+	}
+}
+
+func TestExponentialBackoffDefaults(t *testing.T) {
+	b := &ExponentialBackoff{}
+	b.applyDefaults(2 * time.Second)
+	if b.Initial != 2*time.Second || b.Max != 30*time.Second || b.Multiplier != 2 || b.Jitter != 0.2 {
+		t.Fatalf("defaults %+v", b)
+	}
+}
+
+func TestExponentialBackoffJitter(t *testing.T) {
+	b := &ExponentialBackoff{Initial: time.Second, Jitter: 0.5}
+	b.applyDefaults(time.Second)
+	for range 100 {
+		d, _ := b.Next(1, 0)
+		if d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("wait %v outside [0.5s, 1s]", d)
+		}
+	}
+}
+
+func TestExponentialBackoffMaxElapsed(t *testing.T) {
+	b := &ExponentialBackoff{Initial: time.Second, MaxElapsed: 3 * time.Second, Jitter: -1}
+	b.applyDefaults(time.Second)
+	if _, ok := b.Next(1, time.Second); !ok {
+		t.Fatal("stopped early")
+	}
+	if _, ok := b.Next(2, 2*time.Second); ok {
+		t.Fatal("wait past MaxElapsed allowed")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	var dials int
+	refuse := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials++
+		return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+	}
+	var waits []int
+	c := dialTest(t, nil, Config{
+		Address: "nowhere:1",
+		Dialer:  refuse,
+		Lazy:    true,
+		Backoff: backoffFunc(func(n int) (time.Duration, bool) {
+			waits = append(waits, n)
+			return 0, true
+		}),
+		MaxRetriesPerCall: 3,
+		Breaker:           BreakerConfig{Disabled: true},
+	})
+
+	if err := c.Ping(context.Background()); err == nil {
+		t.Fatal("ping succeeded")
+	}
+	if dials != 3 || len(waits) != 2 || waits[0] != 1 || waits[1] != 2 {
+		t.Fatalf("%d dials, waits before retries %v", dials, waits)
+	}
+}
+
+func TestRetryBackoffCancel(t *testing.T) {
+	refuse := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+	}
+	c := dialTest(t, nil, Config{
+		Address:           "nowhere:1",
+		Dialer:            refuse,
+		Lazy:              true,
+		Backoff:           &ExponentialBackoff{Initial: time.Hour},
+		MaxRetriesPerCall: 3,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := c.Ping(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("backoff ignored ctx for %v", d)
+	}
+}
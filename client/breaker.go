@@ -0,0 +1,280 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+type BreakerConfig struct {
+	// consecutive failures that open a breaker (default = 5)
+	ConsecutiveFailures int
+	// failure ratio over the last Window calls that opens a breaker
+	// (default = 0.5)
+	ErrorRate float64
+	// calls the error rate is measured over (default = 20)
+	Window int
+	// how long an open breaker rejects calls before letting a trial call
+	// through (default = 5 s)
+	OpenTimeout time.Duration
+	// don't use circuit breakers
+	Disabled bool
+}
+
+func (b *BreakerConfig) applyDefaults() {
+	if b.ConsecutiveFailures <= 0 {
+		b.ConsecutiveFailures = 5
+	}
+	if b.ErrorRate <= 0 || b.ErrorRate > 1 {
+		b.ErrorRate = 0.5
+	}
+	if b.Window <= 0 {
+		b.Window = 20
+	}
+	if b.OpenTimeout <= 0 {
+		b.OpenTimeout = 5 * time.Second
+	}
+}
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	// calls are rejected with ErrCircuitOpen
+	BreakerOpen
+	// one trial call at a time decides whether to close or reopen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type BreakerStateChange struct {
+	Endpoint string
+	// index of the conn within its lane, -1 for an endpoint breaker
+	Conn     int
+	Lane     Lane
+	From, To BreakerState
+}
+
+// breaker guards a pooled conn or an endpoint. Only connection errors and
+// timeouts count as failures; an error answered by the server means it is
+// still serving.
+type breaker struct {
+	cfg      *BreakerConfig
+	onChange func(from, to BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	since    time.Time
+	failures int    // consecutive
+	window   []bool // recent outcomes, true = failed
+	next     int
+	failed   int
+	trial    time.Time // start of the current half-open trial
+}
+
+func newBreaker(cfg *BreakerConfig, onChange func(from, to BreakerState)) *breaker {
+	return &breaker{
+		cfg:      cfg,
+		onChange: onChange,
+		since:    time.Now(),
+		window:   make([]bool, 0, cfg.Window),
+	}
+}
+
+// allow reports whether a call may go through, moving an open breaker to
+// half-open once OpenTimeout has passed. A half-open breaker lets one trial
+// through per OpenTimeout until a result is recorded.
+func (b *breaker) allow() bool {
+	if b.cfg.Disabled {
+		return true
+	}
+
+	b.mu.Lock()
+	from := b.state
+	ok := true
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.since) < b.cfg.OpenTimeout {
+			ok = false
+			break
+		}
+		b.set(BreakerHalfOpen)
+		b.trial = time.Now()
+	case BreakerHalfOpen:
+		if time.Since(b.trial) < b.cfg.OpenTimeout {
+			ok = false
+			break
+		}
+		b.trial = time.Now()
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+	return ok
+}
+
+// ready is allow without taking the trial slot.
+func (b *breaker) ready() bool {
+	if b.cfg.Disabled {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		return time.Since(b.since) >= b.cfg.OpenTimeout
+	case BreakerHalfOpen:
+		return time.Since(b.trial) >= b.cfg.OpenTimeout
+	}
+	return true
+}
+
+// giveBack returns the half-open trial taken by allow for a call that
+// didn't run.
+func (b *breaker) giveBack() {
+	if b.cfg.Disabled {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerHalfOpen {
+		b.trial = time.Time{}
+	}
+}
+
+func (b *breaker) record(failed bool) {
+	if b.cfg.Disabled {
+		return
+	}
+
+	b.mu.Lock()
+	from := b.state
+	switch b.state {
+	case BreakerClosed:
+		if failed {
+			b.failures++
+		} else {
+			b.failures = 0
+		}
+		b.observe(failed)
+		if b.failures >= b.cfg.ConsecutiveFailures ||
+			len(b.window) == b.cfg.Window && float64(b.failed) >= b.cfg.ErrorRate*float64(b.cfg.Window) {
+			b.set(BreakerOpen)
+		}
+	case BreakerHalfOpen:
+		if failed {
+			b.set(BreakerOpen)
+		} else {
+			b.set(BreakerClosed)
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+}
+
+// observe adds one outcome to the window, dropping the oldest once full.
+func (b *breaker) observe(failed bool) {
+	if len(b.window) < b.cfg.Window {
+		b.window = append(b.window, failed)
+	} else {
+		if b.window[b.next] {
+			b.failed--
+		}
+		b.window[b.next] = failed
+		b.next = (b.next + 1) % b.cfg.Window
+	}
+	if failed {
+		b.failed++
+	}
+}
+
+// set changes state and starts counting afresh. The caller holds b.mu.
+func (b *breaker) set(s BreakerState) {
+	b.state = s
+	b.since = time.Now()
+	b.failures = 0
+	b.window = b.window[:0]
+	b.next = 0
+	b.failed = 0
+}
+
+func (b *breaker) changed(from, to BreakerState) {
+	if from != to && b.onChange != nil {
+		b.onChange(from, to)
+	}
+}
+
+func (b *breaker) current() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// admit reports whether both w and the endpoint it is connected to accept
+// a call. w is checked out, so its breaker is asked first and gets back the
+// trial it granted when the shared endpoint breaker refuses.
+func (c *Client) admit(w *conn) bool {
+	if !w.breaker.allow() {
+		return false
+	}
+	if ep := w.connectedTo(); ep != nil && !ep.breaker.allow() {
+		w.breaker.giveBack()
+		return false
+	}
+	return true
+}
+
+// recordOutcome feeds the result of a call on w to its breakers.
+func recordOutcome(w *conn, ep *endpoint, failed bool) {
+	w.breaker.record(failed)
+	if ep != nil {
+		ep.breaker.record(failed)
+	}
+}
+
+func (c *Client) newConnBreaker(w *conn) *breaker {
+	return newBreaker(&c.cfg.Breaker, func(from, to BreakerState) {
+		ev := BreakerStateChange{Conn: w.id, Lane: w.lane, From: from, To: to}
+		if ep := w.connectedTo(); ep != nil {
+			ev.Endpoint = ep.addr
+		}
+		c.notifyBreaker(ev)
+	})
+}
+
+// newEndpointBreaker returns the breaker of e. Opening it moves the conns
+// on e to other endpoints, as reportFailure does.
+func (c *Client) newEndpointBreaker(e *endpoint) *breaker {
+	return newBreaker(&c.cfg.Breaker, func(from, to BreakerState) {
+		if to == BreakerOpen {
+			c.moveOff(e)
+		}
+		c.notifyBreaker(BreakerStateChange{Endpoint: e.addr, Conn: -1, From: from, To: to})
+	})
+}
+
+func (c *Client) notifyBreaker(ev BreakerStateChange) {
+	if c.cfg.OnBreakerStateChange != nil {
+		c.cfg.OnBreakerStateChange(ev)
+	}
+}
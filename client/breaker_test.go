@@ -0,0 +1,148 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(cfg BreakerConfig) *breaker {
+	cfg.applyDefaults()
+	return newBreaker(&cfg, nil)
+}
+
+func TestBreakerConsecutiveFailures(t *testing.T) {
+	b := newTestBreaker(BreakerConfig{ConsecutiveFailures: 3, OpenTimeout: 20 * time.Millisecond})
+	for range 2 {
+		b.record(true)
+	}
+	b.record(false)
+	b.record(true)
+	if b.current() != BreakerClosed {
+		t.Fatal("opened before 3 consecutive failures")
+	}
+	b.record(true)
+	b.record(true)
+	if b.current() != BreakerOpen || b.allow() {
+		t.Fatal("not open after 3 consecutive failures")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !b.allow() || b.current() != BreakerHalfOpen {
+		t.Fatal("no trial after OpenTimeout")
+	}
+	if b.allow() {
+		t.Fatal("second trial let through")
+	}
+	b.record(false)
+	if b.current() != BreakerClosed || !b.allow() {
+		t.Fatal("successful trial didn't close")
+	}
+}
+
+func TestBreakerErrorRate(t *testing.T) {
+	b := newTestBreaker(BreakerConfig{ConsecutiveFailures: 100, ErrorRate: 0.5, Window: 10})
+	for i := range 9 {
+		b.record(i%2 == 1)
+	}
+	if b.current() != BreakerClosed {
+		t.Fatal("opened before the window filled")
+	}
+	b.record(false)
+	if b.current() != BreakerClosed {
+		t.Fatal("opened at 4 of 10 failures")
+	}
+	b.record(true)
+	if b.current() != BreakerOpen {
+		t.Fatal("not open at 5 of 10 failures")
+	}
+}
+
+func TestBreakerFailedTrialReopens(t *testing.T) {
+	b := newTestBreaker(BreakerConfig{ConsecutiveFailures: 1, OpenTimeout: 10 * time.Millisecond})
+	b.record(true)
+	time.Sleep(10 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("no trial")
+	}
+	b.record(true)
+	if b.current() != BreakerOpen || b.allow() {
+		t.Fatal("failed trial didn't reopen")
+	}
+}
+
+// halfOpen puts b in half-open with its trial free.
+func halfOpenBreaker(b *breaker) {
+	b.mu.Lock()
+	b.set(BreakerHalfOpen)
+	b.trial = time.Time{}
+	b.mu.Unlock()
+}
+
+func openBreaker(b *breaker) {
+	b.mu.Lock()
+	b.set(BreakerOpen)
+	b.mu.Unlock()
+}
+
+func TestAdmitKeepsTrials(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 1, Breaker: BreakerConfig{OpenTimeout: time.Hour}})
+	w := c.pool.all()[0]
+	ep := w.connectedTo()
+
+	// the endpoint refuses: the conn keeps its trial
+	halfOpenBreaker(w.breaker)
+	openBreaker(ep.breaker)
+	if c.admit(w) {
+		t.Fatal("admitted with the endpoint breaker open")
+	}
+	if !w.breaker.allow() {
+		t.Fatal("conn trial used up by a refused call")
+	}
+
+	// the conn refuses: the endpoint isn't asked
+	openBreaker(w.breaker)
+	halfOpenBreaker(ep.breaker)
+	if c.admit(w) {
+		t.Fatal("admitted with the conn breaker open")
+	}
+	if !ep.breaker.allow() {
+		t.Fatal("endpoint trial used up by a refused call")
+	}
+}
+
+func TestEndpointBreakerSparesBusyConns(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 2, Breaker: BreakerConfig{ConsecutiveFailures: 1}})
+	busy, idle := c.pool.all()[0], c.pool.all()[1]
+	ep := busy.connectedTo()
+	if !c.pool.take(busy) {
+		t.Fatal("conn 0 not idle")
+	}
+
+	recordOutcome(idle, ep, true)
+	if ep.breaker.current() != BreakerOpen {
+		t.Fatal("endpoint breaker not open")
+	}
+	if idle.connectedTo() != nil {
+		t.Fatal("idle conn left on the endpoint")
+	}
+	if busy.connectedTo() != ep {
+		t.Fatal("busy conn cut off")
+	}
+	c.pool.put(busy)
+	if busy.connectedTo() != nil {
+		t.Fatal("released conn left on the endpoint")
+	}
+}
+
+func TestBreakerRejectsCalls(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 1, Breaker: BreakerConfig{OpenTimeout: time.Hour}})
+	openBreaker(c.pool.all()[0].breaker)
+	_, err := c.ListTables(t.Context())
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if st := c.PoolStats(); st.OpenBreakers != 1 {
+		t.Fatalf("pool stats %+v", st)
+	}
+}
@@ -0,0 +1,354 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// CacheConfig enables a read-through cache of Query and StreamQuery
+// results for ranges that no longer change. It needs Horizon or
+// CheckLastUpdated, or rows written late to a past range would go unseen.
+// A hit runs as a call of its own for Shutdown, the client-side limits and
+// the call interceptors, but without attempts.
+type CacheConfig struct {
+	// budget for cached responses, least recently used dropped first
+	// (0 = no cache)
+	MaxBytes int
+	// only queries whose To is at least this long ago are cached (0 = any
+	// past To, with CheckLastUpdated)
+	Horizon time.Duration
+	// look up Table.last_updated before serving an entry and drop entries
+	// cached before the table last changed, e.g. by other clients
+	CheckLastUpdated bool
+}
+
+// CacheStats counts lookups of cacheable queries since Dial.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int
+}
+
+type cacheKey struct {
+	table, prefix string
+	from, to      int64
+	limit         int64
+	reverse, head bool
+	bucket        uint64
+	// a StreamQuery result, replayed batch by batch as cut by its
+	// StreamOptions
+	stream                    bool
+	rowsPerChunk, targetBytes uint32
+	// only set without DecompressPayloads, where rows stay compressed
+	compression proto.CompressionMethod
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	resp *proto.QueryResponse
+	// batches of a StreamQuery result
+	batches []cacheBatch
+	size    int
+	// Table.last_updated when cached, with CheckLastUpdated
+	stamp time.Time
+}
+
+type queryCache struct {
+	cfg CacheConfig
+	raw bool
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	lru     *list.List // most recently used first
+	bytes   int
+	// bumped by every invalidation, so results fetched meanwhile aren't
+	// stored
+	gen uint64
+
+	hits, misses, evictions uint64
+}
+
+type cacheBatch struct {
+	index uint32
+	rows  int
+}
+
+func newQueryCache(cfg CacheConfig, raw bool) *queryCache {
+	if cfg.MaxBytes <= 0 || cfg.Horizon <= 0 && !cfg.CheckLastUpdated {
+		return nil
+	}
+	return &queryCache{
+		cfg:     cfg,
+		raw:     raw,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// key returns the cache key of req, or false if its range may still
+// change.
+func (q *queryCache) key(req *proto.QueryRequest, stream bool) (cacheKey, bool) {
+	f := req.FilterOptions
+	if f.GetTo() == nil {
+		return cacheKey{}, false
+	}
+	to := f.GetTo().AsTime()
+	if to.After(time.Now().Add(-q.cfg.Horizon)) {
+		return cacheKey{}, false
+	}
+
+	k := cacheKey{
+		table:   req.TableName,
+		prefix:  req.Prefix,
+		from:    minTime,
+		to:      to.UnixNano(),
+		limit:   f.GetLimit(),
+		reverse: f.GetReverse(),
+		head:    req.Head,
+		bucket:  req.AggregationOptions.GetTimeBucket(),
+		stream:  stream,
+	}
+	if f.GetFrom() != nil {
+		k.from = f.GetFrom().AsTime().UnixNano()
+	}
+	if stream {
+		k.rowsPerChunk = req.StreamOptions.GetRowsPerChunk()
+		k.targetBytes = req.StreamOptions.GetTargetBytes()
+	}
+	if q.raw {
+		k.compression = req.Compression
+	}
+	return k, true
+}
+
+// get returns the entry for k, dropping it if it is older than stamp.
+func (q *queryCache) get(k cacheKey, stamp time.Time) *cacheEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	el, ok := q.entries[k]
+	if ok && el.Value.(*cacheEntry).stamp.Before(stamp) {
+		q.remove(el)
+		ok = false
+	}
+	if !ok {
+		q.misses++
+		return nil
+	}
+	q.hits++
+	q.lru.MoveToFront(el)
+	return el.Value.(*cacheEntry)
+}
+
+// generation returns the value put needs to see unchanged.
+func (q *queryCache) generation() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.gen
+}
+
+// put stores e unless the cache was invalidated since gen, evicting the
+// least recently used entries to make room.
+func (q *queryCache) put(gen uint64, e *cacheEntry) {
+	e.size = e.resp.SizeVT()
+	if e.size > q.cfg.MaxBytes {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if gen != q.gen {
+		return
+	}
+	if el, ok := q.entries[e.key]; ok {
+		q.remove(el)
+	}
+	for q.bytes+e.size > q.cfg.MaxBytes {
+		q.remove(q.lru.Back())
+		q.evictions++
+	}
+	q.entries[e.key] = q.lru.PushFront(e)
+	q.bytes += e.size
+}
+
+func (q *queryCache) remove(el *list.Element) {
+	e := q.lru.Remove(el).(*cacheEntry)
+	delete(q.entries, e.key)
+	q.bytes -= e.size
+}
+
+// invalidate drops the entries of table whose prefix and range overlap a
+// write. An empty prefix stands for every prefix.
+func (q *queryCache) invalidate(table, prefix string, from, to int64) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.gen++
+	for el := q.lru.Front(); el != nil; {
+		next := el.Next()
+		k := el.Value.(*cacheEntry).key
+		if (table == "" || k.table == table) &&
+			(prefix == "" || k.prefix == "" || k.prefix == prefix) &&
+			k.from < to && from < k.to {
+			q.remove(el)
+		}
+		el = next
+	}
+}
+
+const (
+	minTime int64 = -1 << 63
+	maxTime int64 = 1<<63 - 1
+)
+
+// invalidateInsert drops the entries the rows of req may change.
+func (q *queryCache) invalidateInsert(req *proto.InsertRequest) {
+	if q == nil || len(req.Rows) == 0 {
+		return
+	}
+	lo, hi := maxTime, minTime
+	for _, r := range req.Rows {
+		ts := r.GetTimestamp().AsTime().UnixNano()
+		lo, hi = min(lo, ts), max(hi, ts)
+	}
+	q.invalidate(req.TableName, req.Prefix, lo, hi+1)
+}
+
+// invalidateDelete drops the entries req may change.
+func (q *queryCache) invalidateDelete(req *proto.DeleteRequest) {
+	if q == nil {
+		return
+	}
+	lo, hi := minTime, maxTime
+	if f := req.FilterOptions; f != nil {
+		if f.From != nil {
+			lo = f.From.AsTime().UnixNano()
+		}
+		if f.To != nil {
+			hi = f.To.AsTime().UnixNano()
+		}
+	}
+	q.invalidate(req.TableName, req.Prefix, lo, hi)
+}
+
+// CacheStats reports the query cache; all zero without Config.Cache.
+func (c *Client) CacheStats() CacheStats {
+	q := c.cache
+	if q == nil {
+		return CacheStats{}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return CacheStats{
+		Hits:      q.hits,
+		Misses:    q.misses,
+		Evictions: q.evictions,
+		Entries:   len(q.entries),
+		Bytes:     q.bytes,
+	}
+}
+
+// cachedQuery is the cache's view of one cacheable query.
+type cachedQuery struct {
+	key   cacheKey
+	stamp time.Time
+	gen   uint64
+	// nil on a miss
+	hit *cacheEntry
+}
+
+// lookupCache returns the cache's view of req, or nil when it can't be
+// cached.
+func (c *Client) lookupCache(ctx context.Context, m Method, req *proto.QueryRequest, opts []CallOption) (*cachedQuery, error) {
+	if c.cache == nil {
+		return nil, nil
+	}
+	k, ok := c.cache.key(req, m.Stream())
+	if !ok {
+		return nil, nil
+	}
+
+	cq := &cachedQuery{key: k, gen: c.cache.generation()}
+	if c.cache.cfg.CheckLastUpdated {
+		t, err := c.GetTable(ctx, req.TableName, opts...)
+		if err != nil {
+			return nil, err
+		}
+		cq.stamp = t.GetLastUpdated().AsTime()
+	}
+	cq.hit = c.cache.get(k, cq.stamp)
+	return cq, nil
+}
+
+// store caches resp, fetched after cq was looked up.
+func (c *Client) storeCache(cq *cachedQuery, resp *proto.QueryResponse, batches []cacheBatch) {
+	c.cache.put(cq.gen, &cacheEntry{key: cq.key, resp: resp, batches: batches, stamp: cq.stamp})
+}
+
+// cacheFill collects the rows of a StreamQuery for the cache, up to max
+// bytes.
+type cacheFill struct {
+	max     int
+	size    int
+	rows    []*proto.Row
+	batches []cacheBatch
+}
+
+// wrap returns p with callbacks that copy every row before p's callbacks
+// see it.
+func (f *cacheFill) wrap(p *StreamQueryParams) *StreamQueryParams {
+	w := *p
+	w.onRow = func(r *proto.Row) error {
+		if f.size <= f.max {
+			cp := r.CloneVT()
+			f.rows = append(f.rows, cp)
+			f.size += cp.SizeVT()
+		}
+		return p.onRow(r)
+	}
+	w.onBatch = func(index uint32, rows []*proto.Row) error {
+		f.batches = append(f.batches, cacheBatch{index: index, rows: len(rows)})
+		return p.onBatch(index, rows)
+	}
+	return &w
+}
+
+// store caches the collected rows with resp unless they went over budget.
+func (f *cacheFill) store(c *Client, cq *cachedQuery, resp *proto.QueryResponse) {
+	if f.size > f.max {
+		return
+	}
+	resp = resp.CloneVT()
+	resp.Rows = f.rows
+	c.storeCache(cq, resp, f.batches)
+}
+
+// replay hands the rows of e to p's callbacks as the original StreamQuery
+// did.
+func (e *cacheEntry) replay(p *StreamQueryParams) (*proto.QueryResponse, error) {
+	resp := e.resp.CloneVT()
+	rows := resp.Rows
+	resp.Rows = nil
+	for _, b := range e.batches {
+		batch := rows[:b.rows]
+		rows = rows[b.rows:]
+		for _, r := range batch {
+			if err := p.onRow(r); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.onBatch(b.index, batch); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
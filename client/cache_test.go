@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testCache = CacheConfig{MaxBytes: 1 << 20, Horizon: time.Hour}
+
+// lateRow is written into the seeded range after it was cached.
+var lateRow = []*proto.Row{{Timestamp: timestamppb.New(time.Unix(5, 500)), Data: []byte("late")}}
+
+// batchedQuery is rangeQuery streamed in batches of four rows.
+func batchedQuery(table string, from, to int) *proto.QueryRequest {
+	req := rangeQuery(table, from, to)
+	req.StreamOptions = &proto.StreamOptions{RowsPerChunk: Uint32(4)}
+	return req
+}
+
+// streamBatches runs req as a StreamQuery and returns the size and index
+// of every batch.
+func streamBatches(t *testing.T, c *Client, req *proto.QueryRequest) (sizes []int, indexes []uint32) {
+	t.Helper()
+	p := NewStreamQueryParams().WithRequest(req).WithOnBatch(func(index uint32, rows []*proto.Row) error {
+		sizes = append(sizes, len(rows))
+		indexes = append(indexes, index)
+		return nil
+	})
+	if _, err := c.StreamQuery(context.Background(), p); err != nil {
+		t.Fatal(err)
+	}
+	return sizes, indexes
+}
+
+func TestCacheHit(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{Cache: testCache})
+	seed(t, c, "t", "", 10)
+
+	for range 2 {
+		resp, err := c.Query(context.Background(), rangeQuery("t", 0, 10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRows(t, resp.Rows, 0, 10, false)
+	}
+	if st := c.CacheStats(); st.Hits != 1 || st.Misses != 1 || st.Entries != 1 {
+		t.Fatalf("cache stats %+v", st)
+	}
+
+	// an insert into the range drops the entry
+	if _, err := c.Insert(context.Background(), &proto.InsertRequest{TableName: "t", Rows: lateRow}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Query(context.Background(), rangeQuery("t", 0, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Rows) != 11 {
+		t.Fatalf("got %d rows after an insert", len(resp.Rows))
+	}
+}
+
+func TestCacheStreamKeptApart(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{Cache: testCache})
+	seed(t, c, "t", "", 10)
+
+	if _, err := c.Query(context.Background(), batchedQuery("t", 0, 10)); err != nil {
+		t.Fatal(err)
+	}
+	// a Query result must not be replayed as a single batch
+	sizes, indexes := streamBatches(t, c, batchedQuery("t", 0, 10))
+	if st := c.CacheStats(); st.Hits != 0 || st.Entries != 2 {
+		t.Fatalf("cache stats %+v", st)
+	}
+	hitSizes, hitIndexes := streamBatches(t, c, batchedQuery("t", 0, 10))
+	if st := c.CacheStats(); st.Hits != 1 {
+		t.Fatalf("cache stats %+v", st)
+	}
+	if len(sizes) != 3 || !slices.Equal(sizes, hitSizes) || !slices.Equal(indexes, hitIndexes) {
+		t.Fatalf("streamed batches %v %v, replayed %v %v", sizes, indexes, hitSizes, hitIndexes)
+	}
+}
+
+func TestCacheStreamChunking(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{Cache: testCache})
+	seed(t, c, "t", "", 10)
+
+	if sizes, _ := streamBatches(t, c, batchedQuery("t", 0, 10)); len(sizes) != 3 {
+		t.Fatalf("batches %v", sizes)
+	}
+	// the same range cut in other batches isn't replayed as cached
+	req := batchedQuery("t", 0, 10)
+	req.StreamOptions.RowsPerChunk = Uint32(5)
+	if sizes, _ := streamBatches(t, c, req); !slices.Equal(sizes, []int{5, 5}) {
+		t.Fatalf("batches %v", sizes)
+	}
+	if st := c.CacheStats(); st.Hits != 0 || st.Entries != 2 {
+		t.Fatalf("cache stats %+v", st)
+	}
+}
+
+func TestCacheNeedsHorizon(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{Cache: CacheConfig{MaxBytes: 1 << 20}})
+	seed(t, c, "t", "", 10)
+
+	for range 2 {
+		if _, err := c.Query(context.Background(), rangeQuery("t", 0, 10)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if st := c.CacheStats(); st != (CacheStats{}) {
+		t.Fatalf("cache without Horizon or CheckLastUpdated: %+v", st)
+	}
+}
+
+func TestCacheCheckLastUpdated(t *testing.T) {
+	s := newTestServer(t)
+	c := dialTest(t, s, Config{Cache: CacheConfig{MaxBytes: 1 << 20, CheckLastUpdated: true}})
+	seed(t, c, "t", "", 10)
+	if _, err := c.Query(context.Background(), rangeQuery("t", 0, 10)); err != nil {
+		t.Fatal(err)
+	}
+
+	// another client's write doesn't invalidate this one's cache
+	other := dialTest(t, s, Config{})
+	time.Sleep(time.Millisecond)
+	if _, err := other.Insert(context.Background(), &proto.InsertRequest{TableName: "t", Rows: lateRow}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Query(context.Background(), rangeQuery("t", 0, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Rows) != 11 || c.CacheStats().Hits != 0 {
+		t.Fatalf("got %d rows, cache stats %+v", len(resp.Rows), c.CacheStats())
+	}
+}
+
+func TestCacheHitTakesLimits(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{Cache: testCache, MaxInFlight: 1, FailFastOnLimit: true})
+	seed(t, c, "t", "", 10)
+	seed(t, c, "other", "", 10)
+	if _, err := c.Query(context.Background(), rangeQuery("t", 0, 10)); err != nil {
+		t.Fatal(err)
+	}
+
+	release := holdStream(t, c, "other")
+	_, err := c.Query(context.Background(), rangeQuery("t", 0, 10))
+	var le *LimitError
+	if !errors.As(err, &le) || le.Limit != "in-flight" {
+		t.Fatalf("cache hit over the in-flight limit: %v", err)
+	}
+	if err := release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Query(context.Background(), rangeQuery("t", 0, 10)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCacheHitAfterShutdown(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{Cache: testCache})
+	seed(t, c, "t", "", 10)
+	if _, err := c.Query(context.Background(), rangeQuery("t", 0, 10)); err != nil {
+		t.Fatal(err)
+	}
+	streamBatches(t, c, rangeQuery("t", 0, 10))
+	if st := c.CacheStats(); st.Entries != 2 {
+		t.Fatalf("cache stats %+v", st)
+	}
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Query(context.Background(), rangeQuery("t", 0, 10)); !errors.Is(err, ErrShutdown) {
+		t.Fatalf("cache hit after Shutdown: %v", err)
+	}
+	if _, err := c.StreamQuery(context.Background(), NewStreamQueryParams().WithRequest(rangeQuery("t", 0, 10))); !errors.Is(err, ErrShutdown) {
+		t.Fatalf("stream cache hit after Shutdown: %v", err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/nonhumantrades/flowdb-go/pkg/compression"
 	"github.com/nonhumantrades/flowdb-go/proto"
 	"storj.io/drpc"
 	"storj.io/drpc/drpcconn"
@@ -24,20 +26,91 @@ import (
 )
 
 type Config struct {
-	// host:port
+	// host:port, or tcp://host:port, unix:///path/to/socket
 	Address string
-	// optional tls config
+	// further endpoints in the same form; the pool is spread across Address
+	// and Endpoints and fails over between them
+	Endpoints []string
+	// network for addresses without a scheme (default = "tcp")
+	Network string
+	// opens transports instead of net.Dialer, e.g. to an in-process server;
+	// gets the network and address with the scheme stripped
+	Dialer func(ctx context.Context, network, addr string) (net.Conn, error)
+	// optional tls config, see NewTLSConfig
 	TLSConfig *tls.Config
-	// dial / call timeout (default = 10 s)
+	// dial timeout, and per-attempt deadline for unary calls whose ctx has
+	// none (default = 10 s)
 	Timeout time.Duration
 	// separate connections (default = 8)
 	PoolSize int
-	// wait before redial (default = 2 s)
+	// connections the pool may grow to when every conn is busy; calls wait
+	// for a free conn beyond that (default = PoolSize)
+	MaxPoolSize int
+	// dedicated connections for streaming and bulk RPCs (StreamQuery, Backup,
+	// BackupToS3, RestoreFromS3), dialed on first use; bulk calls wait for
+	// one of these instead of taking conns from the pool (0 = share the pool)
+	StreamPoolSize int
+	// first wait before redial when Backoff is unset (default = 2 s)
 	ReconnectInterval time.Duration
 	// retries across pool (default = PoolSize)
 	MaxRetriesPerCall int
+	// the server deduplicates inserts by idempotency_key, so Insert may be
+	// retried after a connection error; a server that doesn't would write
+	// the rows twice
+	IdempotentInserts bool
+	// wait between retries (default = ExponentialBackoff from ReconnectInterval)
+	Backoff BackoffPolicy
 	// DRPC receive buffer (default = 512 MiB)
 	MaxBufferBytes int
+	// consecutive failures before an endpoint is avoided (default = 3)
+	UnhealthyThreshold int
+	// interval between probes of unhealthy endpoints (default = 5 s)
+	HealthCheckInterval time.Duration
+	// don't dial in Dial; connections are made on first use
+	Lazy bool
+	// make Dial block until this many pooled connections are up, redialing
+	// until ctx is done (0 = one dial pass that must connect at least once)
+	WaitForReady int
+	// interval between Ping probes of idle pooled connections; dead ones
+	// are redialed (default = 30 s, < 0 = off)
+	KeepaliveInterval time.Duration
+	// called whenever a pooled connection changes state
+	OnConnStateChange func(ConnStateChange)
+	// circuit breakers on every pooled connection and endpoint
+	Breaker BreakerConfig
+	// called whenever a circuit breaker changes state
+	OnBreakerStateChange func(BreakerStateChange)
+	// calls running at once, streams included, before further calls wait
+	// (0 = no limit)
+	MaxInFlight int
+	// token bucket per RPC, keyed by Method.Name
+	MethodRateLimits map[string]RateLimit
+	// token bucket per table, keyed by table name; the "" entry gives every
+	// other table a bucket of its own, of which the 4096 most recently used
+	// are kept
+	TableRateLimits map[string]RateLimit
+	// fail calls over a limit with a *LimitError instead of waiting
+	FailFastOnLimit bool
+	// authenticate calls, e.g. BearerToken, APIKey or RefreshingToken
+	Credentials Credentials
+	// decompress query rows before handing them over; without it rows keep
+	// the compression the response names
+	DecompressPayloads bool
+	// with DecompressPayloads, compress the payload of queries asking for
+	// none by the link each attempt runs over: none to this machine, LZ4
+	// otherwise
+	AutoCompression bool
+	// read-through cache of historical query results
+	Cache CacheConfig
+	// resend slow reads on another connection
+	Hedge HedgeConfig
+	// run around every attempt of unary and streaming calls, the first
+	// outermost
+	UnaryInterceptors  []UnaryInterceptor
+	StreamInterceptors []StreamInterceptor
+	// run around every call, cache hits included, outside the interceptors
+	// of its attempts
+	CallInterceptors []CallInterceptor
 }
 
 type conn struct {
@@ -45,24 +118,57 @@ type conn struct {
 	conn   *drpcconn.Conn
 	client proto.DRPCFlowDBClient
 	cfg    *Config
+	owner  *Client
+	state  ConnState
+
+	breaker *breaker
+
+	id   int
+	lane Lane
+
+	// endpoint this conn dials first, and the one it is connected to
+	home *endpoint
+	ep   *endpoint
+	// endpoint to redial away from once the conn is returned to its pool
+	leaving *endpoint
+
+	// compression AutoCompression asks for over the current transport
+	link proto.CompressionMethod
+	// bytes read off every transport of the conn
+	received atomic.Uint64
+}
+
+type dialFailure struct {
+	ep  *endpoint
+	err error
 }
 
-func (w *conn) dialOnce(ctx context.Context) error {
+// dialOnce connects to the home endpoint, or to the next healthy endpoint
+// when home is unhealthy or refuses. It returns the failed endpoints so the
+// caller can report them once w.mu is released.
+func (w *conn) dialOnce(ctx context.Context) ([]dialFailure, error) {
 	if w.conn != nil {
-		return nil
+		return nil, nil
 	}
 
-	dialer := &net.Dialer{Timeout: w.cfg.Timeout}
+	var failed []dialFailure
 	var nc net.Conn
 	var err error
 
-	if w.cfg.TLSConfig != nil {
-		nc, err = tls.DialWithDialer(dialer, "tcp", w.cfg.Address, w.cfg.TLSConfig)
-	} else {
-		nc, err = dialer.DialContext(ctx, "tcp", w.cfg.Address)
+	for _, e := range w.candidates() {
+		nc, err = dialEndpoint(ctx, w.cfg, e.addr, &w.received)
+		if err == nil {
+			w.ep = e
+			e.success()
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		failed = append(failed, dialFailure{ep: e, err: err})
 	}
 	if err != nil {
-		return err
+		return failed, err
 	}
 
 	opts := drpcconn.Options{
@@ -74,95 +180,385 @@ func (w *conn) dialOnce(ctx context.Context) error {
 	}
 
 	w.conn = drpcconn.NewWithOptions(nc, opts)
+	w.link = linkCompression(nc)
 	w.client = proto.NewDRPCFlowDBClient(w.conn)
-	return nil
+	return failed, nil
+}
+
+var errClosedByRemote = errors.New("connection closed by remote")
+
+// candidates lists the endpoints to dial in order: healthy ones whose
+// breaker lets calls through, starting at home, or every endpoint when there
+// are none.
+func (w *conn) candidates() []*endpoint {
+	eps := w.owner.endpoints
+	start := 0
+	for i, e := range eps {
+		if e == w.home {
+			start = i
+			break
+		}
+	}
+
+	var healthy, all []*endpoint
+	for i := range eps {
+		e := eps[(start+i)%len(eps)]
+		all = append(all, e)
+		if e.isHealthy() && e.breaker.ready() {
+			healthy = append(healthy, e)
+		}
+	}
+	if len(healthy) > 0 {
+		return healthy
+	}
+	return all
 }
 
 func (w *conn) ensureClient(ctx context.Context) (proto.DRPCFlowDBClient, error) {
 	w.mu.RLock()
-	c := w.client
+	c, dc := w.client, w.conn
 	w.mu.RUnlock()
-	if c != nil {
+	if c != nil && !isClosed(dc) {
 		return c, nil
 	}
 
+	var events []ConnStateChange
 	w.mu.Lock()
-	defer w.mu.Unlock()
+
+	// a conn the remote already closed is redialed before anything is sent
+	if w.conn != nil && isClosed(w.conn) {
+		events = w.transition(events, ConnBroken, errClosedByRemote)
+		_ = w.conn.Close()
+		w.conn = nil
+		w.client = nil
+		w.ep = nil
+	}
 
 	if w.client != nil {
-		return w.client, nil
+		c = w.client
+		w.mu.Unlock()
+		return c, nil
 	}
 
-	err := w.dialOnce(ctx)
-	return w.client, err
+	failed, err := w.dialOnce(ctx)
+	if err == nil {
+		events = w.transition(events, ConnReady, nil)
+	}
+	c = w.client
+	w.mu.Unlock()
+
+	w.owner.notify(events)
+	for _, f := range failed {
+		w.owner.reportFailure(f.ep, f.err)
+	}
+	return c, err
+}
+
+// connectedTo returns the endpoint w is connected to, or nil.
+func (w *conn) connectedTo() *endpoint {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.ep
+}
+
+func isClosed(dc *drpcconn.Conn) bool {
+	select {
+	case <-dc.Closed():
+		return true
+	default:
+		return false
+	}
 }
 
 func (w *conn) markBroken() {
+	var events []ConnStateChange
+	w.mu.Lock()
+	if w.conn != nil {
+		events = w.transition(events, ConnBroken, nil)
+		_ = w.conn.Close()
+	}
+	w.conn = nil
+	w.client = nil
+	w.ep = nil
+	w.mu.Unlock()
+
+	w.owner.notify(events)
+}
+
+// leave flags w to be redialed once returned to its pool if it is still
+// connected to e then.
+func (w *conn) leave(e *endpoint) {
+	w.mu.Lock()
+	w.leaving = e
+	w.mu.Unlock()
+}
+
+// redialIfLeaving breaks w if it was flagged by leave and is still
+// connected to the endpoint it is leaving.
+func (w *conn) redialIfLeaving() {
+	w.mu.Lock()
+	e := w.leaving
+	w.leaving = nil
+	stay := e == nil || w.ep != e
+	w.mu.Unlock()
+	if !stay {
+		w.markBroken()
+	}
+}
+
+// disconnect closes w without counting it as broken, e.g. to cancel a
+// stream; it is dialed again on next use.
+func (w *conn) disconnect() {
+	var events []ConnStateChange
 	w.mu.Lock()
 	if w.conn != nil {
+		events = w.transition(events, ConnDisconnected, nil)
 		_ = w.conn.Close()
 	}
 	w.conn = nil
 	w.client = nil
+	w.ep = nil
 	w.mu.Unlock()
+
+	w.owner.notify(events)
 }
 
 type Client struct {
-	cfg  Config
-	pool []*conn
-	rr   uint32
+	cfg        Config
+	pool       *connPool
+	streamPool *connPool // nil unless Config.StreamPoolSize is set
+	endpoints  []*endpoint
+	limiter    *limiter
+	tracker    *tracker
+	comp       *compression.Compression
+	cache      *queryCache // nil unless Config.Cache is set
+	hedger     *hedger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 func Dial(ctx context.Context, cfg Config) (*Client, error) {
 	applyDefaults(&cfg)
 
-	pool := make([]*conn, cfg.PoolSize)
-	for i := 0; i < cfg.PoolSize; i++ {
-		w := &conn{cfg: &cfg}
-		_ = w.dialOnce(ctx)
-		pool[i] = w
+	c := &Client{cfg: cfg}
+	c.limiter = newLimiter(&c.cfg)
+	c.tracker = newTracker()
+	comp, err := compression.NewCompressor()
+	if err != nil {
+		return nil, err
+	}
+	c.comp = comp
+	c.cache = newQueryCache(cfg.Cache, !cfg.DecompressPayloads)
+	c.hedger = newHedger(cfg.Hedge)
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	seen := make(map[string]bool)
+	for _, addr := range append([]string{cfg.Address}, cfg.Endpoints...) {
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		e := newEndpoint(addr)
+		e.breaker = c.newEndpointBreaker(e)
+		c.endpoints = append(c.endpoints, e)
+	}
+	if len(c.endpoints) == 0 {
+		return nil, errors.New("address is required")
+	}
+
+	newConn := func(lane Lane) func(i int) *conn {
+		return func(i int) *conn {
+			w := &conn{
+				cfg:   &c.cfg,
+				owner: c,
+				id:    i,
+				lane:  lane,
+				home:  c.endpoints[i%len(c.endpoints)],
+			}
+			w.breaker = c.newConnBreaker(w)
+			return w
+		}
+	}
+	c.pool = newConnPool(cfg.PoolSize, cfg.MaxPoolSize, newConn(LaneUnary))
+	if cfg.StreamPoolSize > 0 {
+		c.streamPool = newConnPool(cfg.StreamPoolSize, cfg.StreamPoolSize, newConn(LaneStream))
+	}
+	c.wg.Add(1)
+	go c.healthLoop()
+	if cfg.KeepaliveInterval > 0 {
+		c.wg.Add(1)
+		go c.keepaliveLoop()
+	}
+
+	if cfg.Lazy {
+		return c, nil
+	}
+
+	if cfg.WaitForReady > 0 {
+		err = c.WaitForReady(ctx, cfg.WaitForReady)
+	} else if ready, dialErr := c.connect(ctx); ready == 0 {
+		err = fmt.Errorf("%w: %w", ErrNoConnection, dialErr)
+	}
+	if err != nil {
+		_ = c.Close()
+		return nil, err
 	}
 
-	return &Client{cfg: cfg, pool: pool}, nil
+	return c, nil
 }
 
 func applyDefaults(c *Config) {
 	if c.Timeout == 0 {
 		c.Timeout = 10 * time.Second
 	}
+	if c.Network == "" {
+		c.Network = "tcp"
+	}
 	if c.PoolSize <= 0 {
 		c.PoolSize = 8
 	}
 	if c.ReconnectInterval == 0 {
 		c.ReconnectInterval = 2 * time.Second
 	}
+	if c.MaxPoolSize < c.PoolSize {
+		c.MaxPoolSize = c.PoolSize
+	}
 	if c.MaxRetriesPerCall <= 0 {
 		c.MaxRetriesPerCall = c.PoolSize
 	}
 	if c.MaxBufferBytes == 0 {
 		c.MaxBufferBytes = 512 << 20 // 512 MiB
 	}
+	if c.UnhealthyThreshold <= 0 {
+		c.UnhealthyThreshold = 3
+	}
+	if c.HealthCheckInterval <= 0 {
+		c.HealthCheckInterval = 5 * time.Second
+	}
+	if c.KeepaliveInterval == 0 {
+		c.KeepaliveInterval = 30 * time.Second
+	}
+	c.Breaker.applyDefaults()
+	switch b := c.Backoff.(type) {
+	case nil:
+		eb := &ExponentialBackoff{}
+		eb.applyDefaults(c.ReconnectInterval)
+		c.Backoff = eb
+	case *ExponentialBackoff:
+		eb := *b
+		eb.applyDefaults(c.ReconnectInterval)
+		c.Backoff = &eb
+	}
 }
 
+// Close closes every connection at once, cutting off calls in flight; see
+// Shutdown to let them finish.
 func (c *Client) Close() error {
+	c.cancel()
+	c.wg.Wait()
+
 	var firstErr error
-	for _, w := range c.pool {
+	var events []ConnStateChange
+	for _, w := range c.conns() {
 		w.mu.Lock()
+		events = w.transition(events, ConnDisconnected, nil)
 		if w.conn != nil {
 			if err := w.conn.Close(); err != nil && firstErr == nil {
 				firstErr = err
 			}
 			w.conn = nil
 			w.client = nil
+			w.ep = nil
 		}
 		w.mu.Unlock()
 	}
+	c.notify(events)
 	return firstErr
 }
 
-func (c *Client) pickConn() *conn {
-	idx := atomic.AddUint32(&c.rr, 1)
-	return c.pool[int(idx)%len(c.pool)]
+// conns returns the conns of every lane.
+func (c *Client) conns() []*conn {
+	out := c.pool.all()
+	if c.streamPool != nil {
+		out = append(out, c.streamPool.all()...)
+	}
+	return out
+}
+
+// laneFor picks the pool serving m: the stream lane for streaming RPCs when
+// one is configured, unless the call chose a lane with WithLane.
+func (c *Client) laneFor(m Method, o *callOptions) *connPool {
+	if c.streamPool == nil {
+		return c.pool
+	}
+	switch o.lane {
+	case LaneUnary:
+		return c.pool
+	case LaneStream:
+		return c.streamPool
+	}
+	if m.Stream() {
+		return c.streamPool
+	}
+	return c.pool
+}
+
+// pickConn checks out a conn from p for one attempt, skipping conns whose
+// breaker is open. With affinity the attempt waits for its pinned conn;
+// later attempts move to the following conns. It fails with ErrCircuitOpen
+// rather than wait when every idle conn was skipped.
+func (c *Client) pickConn(ctx context.Context, p *connPool, o *callOptions, attempt int) (*conn, error) {
+	if o.affinity != nil {
+		want := *o.affinity + uint64(attempt)
+		w, err := p.get(ctx, &want)
+		if err != nil {
+			return nil, err
+		}
+		if !c.admit(w) {
+			p.put(w)
+			return nil, ErrCircuitOpen
+		}
+		return w, nil
+	}
+
+	var skipped []*conn
+	defer func() {
+		for _, w := range skipped {
+			p.put(w)
+		}
+	}()
+
+	for {
+		w := p.tryGet()
+		if w == nil {
+			break
+		}
+		if c.admit(w) {
+			return w, nil
+		}
+		skipped = append(skipped, w)
+	}
+	if len(skipped) > 0 {
+		return nil, ErrCircuitOpen
+	}
+
+	w, err := p.get(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !c.admit(w) {
+		skipped = append(skipped, w)
+		return nil, ErrCircuitOpen
+	}
+	return w, nil
+}
+
+// IsConnectionError reports whether err means the connection failed or the
+// call timed out, rather than the server answering with an error.
+func IsConnectionError(err error) bool {
+	return isConnectionError(err)
 }
 
 func isConnectionError(err error) bool {
@@ -188,6 +584,8 @@ func isConnectionError(err error) bool {
 		"no route to host",
 		"connection timed out",
 		"transport closed",
+		"closed pipe",
+		"use of closed network connection",
 	}
 
 	low := strings.ToLower(errStr)
@@ -201,83 +599,325 @@ func isConnectionError(err error) bool {
 	return errors.As(err, &netErr)
 }
 
-func call[T any](c *Client, ctx context.Context, fn func(proto.DRPCFlowDBClient) (T, error)) (T, error) {
+// lease is a pooled conn checked out by a call while its response or
+// stream is consumed. release must be called once the call is done with it.
+type lease struct {
+	pool   *connPool
+	conn   *conn
+	cancel context.CancelFunc
+	// frees the call's in-flight slot; set on the lease callConn returns
+	done func()
+	// callOptions.onConn of the call
+	onConn func(held bool)
+	// conn.received when checked out
+	read uint64
+}
+
+// received returns the bytes read off the conn since it was checked out.
+func (l *lease) received() uint64 {
+	return l.conn.received.Load() - l.read
+}
+
+func (l *lease) release() {
+	l.cancel()
+	l.pool.put(l.conn)
+	if l.onConn != nil {
+		l.onConn(false)
+	}
+	if l.done != nil {
+		l.done()
+	}
+}
+
+// abandon releases the lease of a stream the caller stopped reading. drpc
+// cancels a running stream by closing its transport, so the conn is reset
+// here instead of being found closed by the next call.
+func (l *lease) abandon() {
+	l.cancel()
+	l.conn.disconnect()
+	l.release()
+}
+
+// attemptContext derives the context for one attempt. Unary attempts get
+// Config.Timeout unless ctx already has a deadline; WithTimeout applies to
+// every attempt.
+func (c *Client) attemptContext(ctx context.Context, m Method, o *callOptions) (context.Context, context.CancelFunc) {
+	if o.timeout > 0 {
+		return context.WithTimeout(ctx, o.timeout)
+	}
+	if _, ok := ctx.Deadline(); !ok && !m.Stream() {
+		return context.WithTimeout(ctx, c.cfg.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// beginCall records a call for Shutdown and waits for its client-side
+// limits. The returned func ends it.
+func (c *Client) beginCall(ctx context.Context, m Method, o *callOptions) (func(), error) {
+	end, err := c.tracker.begin(m, o.table)
+	if err != nil {
+		return nil, err
+	}
+	free, err := c.limiter.acquire(ctx, m, o.table, o.failFast)
+	if err != nil {
+		end()
+		return nil, err
+	}
+	return func() {
+		free()
+		end()
+	}, nil
+}
+
+// callConn runs fn on pooled connections until it succeeds, fails with a
+// non-connection error or runs out of attempts, and returns the lease of the
+// successful attempt. Connection errors raised by fn are only retried when m
+// is idempotent or o says the call is; dial errors are always retried since
+// nothing was sent.
+// Every attempt runs through the interceptors, which are shown req.
+func callConn[T any](c *Client, ctx context.Context, m Method, o *callOptions, req any, fn func(context.Context, proto.DRPCFlowDBClient) (T, error)) (T, *lease, error) {
+	done, err := c.beginCall(ctx, m, o)
+	if err != nil {
+		var zero T
+		return zero, nil, err
+	}
+	res, l, err := attemptConns(c, ctx, m, o, req, fn)
+	if err != nil {
+		done()
+		return res, nil, err
+	}
+	l.done = done
+	return res, l, nil
+}
+
+// attemptConns is callConn for a call begun by the caller.
+func attemptConns[T any](c *Client, ctx context.Context, m Method, o *callOptions, req any, fn func(context.Context, proto.DRPCFlowDBClient) (T, error)) (T, *lease, error) {
 	var zero T
 	var lastErr error
 
-	for attempt := 0; attempt < c.cfg.MaxRetriesPerCall; attempt++ {
-		w := c.pickConn()
+	p := c.laneFor(m, o)
+	r := c.newRetrier(o)
+	refreshed := false
+	for {
+		ok, err := r.next(ctx)
+		if err != nil {
+			return zero, nil, err
+		}
+		if !ok {
+			return zero, nil, lastErr
+		}
 
-		cli, err := w.ensureClient(ctx)
+		actx, cancel := c.attemptContext(ctx, m, o)
+		w, err := c.pickConn(actx, p, o, r.attempt)
 		if err != nil {
-			lastErr = err
-			if attempt < c.cfg.MaxRetriesPerCall-1 {
-				time.Sleep(c.cfg.ReconnectInterval)
+			cancel()
+			if ctx.Err() != nil {
+				return zero, nil, ctx.Err()
 			}
+			// the attempt deadline passed while waiting for a conn
+			lastErr = err
 			continue
 		}
+		l := &lease{pool: p, conn: w, cancel: cancel, onConn: o.onConn, read: w.received.Load()}
+		if o.onConn != nil {
+			o.onConn(true)
+		}
 
-		res, err := fn(cli)
+		cli, err := w.ensureClient(actx)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.breaker.record(true)
+			}
+			l.release()
+			lastErr = err
+			continue
+		}
+		ep := w.connectedTo()
+		actx = context.WithValue(actx, connKey{}, w)
+
+		info := &CallInfo{
+			Method:  m,
+			Table:   o.table,
+			Attempt: r.attempt,
+			Hedge:   o.hedge,
+			Lane:    w.lane,
+			Conn:    w.id,
+		}
+		if ep != nil {
+			info.Endpoint = ep.addr
+		}
+		if creds := c.cfg.Credentials; creds != nil {
+			md, err := creds.Metadata(actx)
+			if err != nil {
+				l.release()
+				return zero, nil, fmt.Errorf("credentials: %w", err)
+			}
+			actx = WithMetadata(actx, md)
+		}
+		res, err := intercept(c, actx, info, req, func(ctx context.Context) (T, error) {
+			return fn(ctx, cli)
+		})
 		if err == nil {
-			return res, nil
+			if ep != nil {
+				ep.success()
+			}
+			recordOutcome(w, ep, false)
+			return res, l, nil
 		}
+		cancel()
 
 		if isConnectionError(err) {
-			w.markBroken()
-			lastErr = err
-			if attempt < c.cfg.MaxRetriesPerCall-1 {
-				time.Sleep(c.cfg.ReconnectInterval)
+			// the caller giving up says nothing about the endpoint
+			if ctx.Err() == nil {
+				c.reportFailure(ep, err)
+				recordOutcome(w, ep, true)
+				w.markBroken()
+			} else {
+				// drpc closes the transport of a cancelled call
+				w.disconnect()
+			}
+			l.release()
+			if !m.Idempotent() && !o.idempotent {
+				return zero, nil, err
 			}
+			lastErr = err
 			continue
 		}
 
-		return zero, err
+		recordOutcome(w, ep, false)
+		l.release()
+		err = authError(err)
+		// a rejected token gets one more try with a fresh one
+		if rf, ok := c.cfg.Credentials.(Refresher); ok && !refreshed && errors.Is(err, ErrUnauthenticated) {
+			rf.Refresh()
+			refreshed = true
+			r.again()
+			lastErr = err
+			continue
+		}
+		return zero, nil, err
 	}
-
-	return zero, lastErr
 }
 
-func (c *Client) CreateTable(ctx context.Context, name string) (*proto.Table, error) {
-	return call(c, ctx, func(cli proto.DRPCFlowDBClient) (*proto.Table, error) {
-		resp, err := cli.CreateTable(ctx, &proto.CreateTableRequest{
-			Name: name,
-		})
+func call[T any](c *Client, ctx context.Context, m Method, o *callOptions, req any, fn func(context.Context, proto.DRPCFlowDBClient) (T, error)) (T, error) {
+	var res T
+	err := c.interceptCall(ctx, o.callInfo(m), req, func(ctx context.Context) error {
+		r, l, err := hedgeConn(c, ctx, m, o, req, fn)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return resp.Table, nil
+		l.release()
+		res = r
+		return nil
+	})
+	return res, err
+}
+
+func (c *Client) CreateTable(ctx context.Context, name string, opts ...CallOption) (*proto.Table, error) {
+	req := &proto.CreateTableRequest{
+		Name: name,
+	}
+	defer c.cache.invalidate(name, "", minTime, maxTime)
+	resp, err := call(c, ctx, methodCreateTable, c.tableOptions(name, opts), req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.CreateTableResponse, error) {
+		return cli.CreateTable(ctx, req)
 	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Table, nil
 }
 
-func (c *Client) DropTable(ctx context.Context, name string) error {
-	_, err := call(c, ctx, func(cli proto.DRPCFlowDBClient) (*proto.DropTableResponse, error) {
-		return cli.DropTable(ctx, &proto.DropTableRequest{Name: name})
+func (c *Client) DropTable(ctx context.Context, name string, opts ...CallOption) error {
+	req := &proto.DropTableRequest{Name: name}
+	defer c.cache.invalidate(name, "", minTime, maxTime)
+	_, err := call(c, ctx, methodDropTable, c.tableOptions(name, opts), req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.DropTableResponse, error) {
+		return cli.DropTable(ctx, req)
 	})
 	return err
 }
 
-func (c *Client) Insert(ctx context.Context, req *proto.InsertRequest) (*proto.InsertResponse, error) {
-	return call(c, ctx, func(cli proto.DRPCFlowDBClient) (*proto.InsertResponse, error) {
+// Insert writes rows. Every call carries an idempotency key, generated
+// unless req already has one; with Config.IdempotentInserts the call is
+// retried after connection errors, trusting the server to apply it once.
+func (c *Client) Insert(ctx context.Context, req *proto.InsertRequest, opts ...CallOption) (*proto.InsertResponse, error) {
+	if req.IdempotencyKey == "" {
+		req = &proto.InsertRequest{
+			TableName:      req.TableName,
+			Prefix:         req.Prefix,
+			Rows:           req.Rows,
+			IdempotencyKey: newIdempotencyKey(),
+		}
+	}
+	defer c.cache.invalidateInsert(req)
+	o := c.tableOptions(req.TableName, opts)
+	o.idempotent = c.cfg.IdempotentInserts
+	return call(c, ctx, methodInsert, o, req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.InsertResponse, error) {
 		return cli.Insert(ctx, req)
 	})
 }
 
-func (c *Client) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
-	return call(c, ctx, func(cli proto.DRPCFlowDBClient) (*proto.DeleteResponse, error) {
+func (c *Client) Delete(ctx context.Context, req *proto.DeleteRequest, opts ...CallOption) (*proto.DeleteResponse, error) {
+	defer c.cache.invalidateDelete(req)
+	return call(c, ctx, methodDelete, c.tableOptions(req.TableName, opts), req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.DeleteResponse, error) {
 		return cli.Delete(ctx, req)
 	})
 }
 
-func (c *Client) Query(ctx context.Context, req *proto.QueryRequest) (*proto.QueryResponse, error) {
-	return call(c, ctx, func(cli proto.DRPCFlowDBClient) (*proto.QueryResponse, error) {
-		return cli.Query(ctx, req)
+func (c *Client) Query(ctx context.Context, req *proto.QueryRequest, opts ...CallOption) (*proto.QueryResponse, error) {
+	o := c.tableOptions(req.TableName, opts)
+	req = c.queryCompression(req, o)
+
+	var res *proto.QueryResponse
+	info := o.callInfo(methodQuery)
+	err := c.interceptCall(ctx, info, req, func(ctx context.Context) (err error) {
+		res, err = c.query(ctx, req, o, opts, info)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) query(ctx context.Context, req *proto.QueryRequest, o *callOptions, opts []CallOption, info *CallInfo) (*proto.QueryResponse, error) {
+	cq, err := c.lookupCache(ctx, methodQuery, req, opts)
+	if err != nil {
+		return nil, err
+	}
+	if cq != nil && cq.hit != nil {
+		info.CacheHit = true
+		done, err := c.beginCall(ctx, methodQuery, o)
+		if err != nil {
+			return nil, err
+		}
+		defer done()
+		return cq.hit.resp.CloneVT(), nil
+	}
+
+	res, l, err := hedgeConn(c, ctx, methodQuery, o, req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.QueryResponse, error) {
+		return cli.Query(ctx, c.autoCompression(ctx, req, o))
 	})
+	if err != nil {
+		return nil, err
+	}
+	received := l.received()
+	l.release()
+
+	sent, raw := res.CompressedBytes, res.UncompressedBytes
+	if c.cfg.DecompressPayloads {
+		if sent, raw, err = c.decodeRows(res.Rows, res.Compression); err != nil {
+			return nil, err
+		}
+	}
+	res.CompressedBytes, res.UncompressedBytes = wireBytes(received, sent, raw)
+	if cq != nil {
+		c.storeCache(cq, res.CloneVT(), nil)
+	}
+	return res, nil
 }
 
 type StreamQueryParams struct {
 	req     *proto.QueryRequest
 	onRow   func(*proto.Row) error
 	onBatch func(index uint32, rows []*proto.Row) error
+	resume  bool
 }
 
 func NewStreamQueryParams() *StreamQueryParams {
@@ -303,66 +943,113 @@ func (p *StreamQueryParams) WithRequest(req *proto.QueryRequest) *StreamQueryPar
 	return p
 }
 
-func (c *Client) StreamQuery(ctx context.Context, params *StreamQueryParams) (*proto.QueryResponse, error) {
+// WithResume makes StreamQuery pick up where it left off when the
+// connection drops mid-stream, up to the call's retry count: the query is
+// sent again for the rows after the last one delivered, with the remaining
+// limit, and no row reaches onRow or onBatch twice. Batch indexes then
+// count the batches delivered across attempts, and batches left empty are
+// skipped, rather than passing on the server's. A Limit of 0 gets the
+// server's default limit again on every attempt, so set one for exact
+// results.
+func (p *StreamQueryParams) WithResume(resume bool) *StreamQueryParams {
+	p.resume = resume
+	return p
+}
+
+func (c *Client) StreamQuery(ctx context.Context, params *StreamQueryParams, opts ...CallOption) (*proto.QueryResponse, error) {
 	if params.req == nil {
 		return nil, errors.New("request is required")
 	}
 
-	type streamResult struct {
-		stream proto.DRPCFlowDB_StreamQueryClient
-		conn   *conn
-	}
-
-	var result streamResult
-	var lastErr error
+	o := c.tableOptions(params.req.TableName, opts)
+	req := c.queryCompression(params.req, o)
 
-	for attempt := 0; attempt < c.cfg.MaxRetriesPerCall; attempt++ {
-		w := c.pickConn()
+	var resp *proto.QueryResponse
+	info := o.callInfo(methodStreamQuery)
+	err := c.interceptCall(ctx, info, req, func(ctx context.Context) (err error) {
+		resp, err = c.streamQueryResumed(ctx, req, params, o, opts, info)
+		return err
+	})
+	return resp, err
+}
 
-		cli, err := w.ensureClient(ctx)
-		if err != nil {
-			lastErr = err
-			if attempt < c.cfg.MaxRetriesPerCall-1 {
-				time.Sleep(c.cfg.ReconnectInterval)
+// streamQueryResumed runs StreamQuery, resumed after connection errors when
+// params ask for it.
+func (c *Client) streamQueryResumed(ctx context.Context, req *proto.QueryRequest, params *StreamQueryParams, o *callOptions, opts []CallOption, info *CallInfo) (*proto.QueryResponse, error) {
+	cq, err := c.lookupCache(ctx, methodStreamQuery, req, opts)
+	if err != nil {
+		return nil, err
+	}
+	var fill *cacheFill
+	if cq != nil {
+		if cq.hit != nil {
+			info.CacheHit = true
+			done, err := c.beginCall(ctx, methodStreamQuery, o)
+			if err != nil {
+				return nil, err
 			}
-			continue
+			defer done()
+			return cq.hit.replay(params)
 		}
+		fill = &cacheFill{max: c.cfg.Cache.MaxBytes}
+		params = fill.wrap(params)
+	}
 
-		stream, err := cli.StreamQuery(ctx, params.req)
+	cur := newQueryCursor(req, params.resume)
+	resp := &proto.QueryResponse{}
+	for resumes := 0; ; resumes++ {
+		err := c.streamQuery(ctx, o, cur.resume(req), params, cur, resp)
 		if err == nil {
-			result.stream = stream
-			result.conn = w
-			break
-		}
-
-		if isConnectionError(err) {
-			w.markBroken()
-			lastErr = err
-			if attempt < c.cfg.MaxRetriesPerCall-1 {
-				time.Sleep(c.cfg.ReconnectInterval)
+			if fill != nil {
+				fill.store(c, cq, resp)
 			}
-			continue
+			return resp, nil
+		}
+		if !params.resume || !isConnectionError(err) || ctx.Err() != nil || resumes >= o.maxRetries {
+			return nil, err
 		}
-
-		return nil, err
 	}
+}
 
-	if result.stream == nil {
-		return nil, lastErr
+// streamQuery runs one StreamQuery call, delivering rows past cur and
+// adding its footer to resp. The bytes received are counted on the conn;
+// the uncompressed size adds what decompressing the rows added, or without
+// DecompressPayloads what the footer says it would.
+func (c *Client) streamQuery(ctx context.Context, o *callOptions, req *proto.QueryRequest, params *StreamQueryParams, cur *queryCursor, resp *proto.QueryResponse) error {
+	stream, l, err := callConn(c, ctx, methodStreamQuery, o, req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (ClientStream, error) {
+		return streamOf(cli.StreamQuery(ctx, c.autoCompression(ctx, req, o)))
+	})
+	if err != nil {
+		return err
+	}
+	stopped := false
+	defer func() {
+		if stopped {
+			l.abandon()
+		} else {
+			l.release()
+		}
+	}()
+
+	// payload of this call as received and decompressed
+	var sent, raw uint64
+	addBytes := func() {
+		compressed, uncompressed := wireBytes(l.received(), sent, raw)
+		resp.CompressedBytes += compressed
+		resp.UncompressedBytes += uncompressed
 	}
-
-	resp := &proto.QueryResponse{}
-
 	for {
-		chunk, recvErr := result.stream.Recv()
+		chunk, recvErr := recv[*proto.StreamQueryChunk](stream)
 		if recvErr != nil {
 			if recvErr == io.EOF {
-				return resp, nil
+				return nil
 			}
 			if isConnectionError(recvErr) {
-				result.conn.markBroken()
+				l.conn.markBroken()
+				addBytes()
+				resp.Count = cur.delivered
 			}
-			return nil, recvErr
+			return recvErr
 		}
 
 		switch t := chunk.Chunk.(type) {
@@ -370,63 +1057,108 @@ func (c *Client) StreamQuery(ctx context.Context, params *StreamQueryParams) (*p
 			h := t.Header
 			resp.TableName = h.TableName
 			resp.Prefix = h.Prefix
+			resp.Compression = h.Compression
 		case *proto.StreamQueryChunk_Batch:
-			for _, r := range t.Batch.Rows {
+			if c.cfg.DecompressPayloads {
+				s, r, err := c.decodeRows(t.Batch.Rows, resp.Compression)
+				if err != nil {
+					stopped = true
+					return err
+				}
+				sent += s
+				raw += r
+			}
+
+			// batches keep the server's index unless resumed ones renumber
+			rows, index := t.Batch.Rows, t.Batch.Index
+			if params.resume {
+				rows, index = cur.filter(rows), cur.batches
+				if len(rows) == 0 {
+					continue
+				}
+			}
+			for _, r := range rows {
 				if err := params.onRow(r); err != nil {
-					return nil, err
+					stopped = true
+					return err
 				}
 			}
-			if err := params.onBatch(t.Batch.Index, t.Batch.Rows); err != nil {
-				return nil, err
+			if err := params.onBatch(index, rows); err != nil {
+				stopped = true
+				return err
 			}
+			cur.advance(rows)
 		case *proto.StreamQueryChunk_Footer:
 			f := t.Footer
-			resp.Duration = f.Duration
-			resp.Count = f.Count
-			resp.CompressedBytes = f.CompressedBytes
-			resp.UncompressedBytes = f.UncompressedBytes
+			resp.Duration += f.Duration
+			if cur.resumed {
+				// the footer only counts the rows of the last attempt
+				resp.Count = cur.delivered
+			} else {
+				resp.Count += f.Count
+			}
+			if !c.cfg.DecompressPayloads {
+				sent, raw = f.CompressedBytes, f.UncompressedBytes
+			}
+			addBytes()
 			resp.TruncatedByLimit = f.TruncatedByLimit
 		}
 	}
 }
 
-func (c *Client) GetTable(ctx context.Context, name string) (*proto.Table, error) {
-	return call(c, ctx, func(cli proto.DRPCFlowDBClient) (*proto.Table, error) {
-		resp, err := cli.GetTable(ctx, &proto.GetTableRequest{TableName: name})
-		if err != nil {
-			return nil, err
-		}
-		return resp.Table, nil
+func (c *Client) GetTable(ctx context.Context, name string, opts ...CallOption) (*proto.Table, error) {
+	req := &proto.GetTableRequest{TableName: name}
+	resp, err := call(c, ctx, methodGetTable, c.tableOptions(name, opts), req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.GetTableResponse, error) {
+		return cli.GetTable(ctx, req)
 	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Table, nil
 }
 
-func (c *Client) ListTables(ctx context.Context) ([]*proto.Table, error) {
-	return call(c, ctx, func(cli proto.DRPCFlowDBClient) ([]*proto.Table, error) {
-		resp, err := cli.ListTables(ctx, &proto.Empty{})
-		if err != nil {
-			return nil, err
-		}
-		return resp.Tables, nil
+func (c *Client) ListTables(ctx context.Context, opts ...CallOption) ([]*proto.Table, error) {
+	req := &proto.Empty{}
+	resp, err := call(c, ctx, methodListTables, c.callOptions(opts), req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.ListTablesResponse, error) {
+		return cli.ListTables(ctx, req)
 	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Tables, nil
 }
 
-func (c *Client) Backup(ctx context.Context, version uint64, comp proto.CompressionMethod, handler func(*proto.BackupChunk) error) error {
-	stream, err := call(c, ctx, func(cli proto.DRPCFlowDBClient) (proto.DRPCFlowDB_BackupClient, error) {
-		return cli.Backup(ctx, &proto.BackupRequest{Version: version, Compression: comp})
+func (c *Client) Backup(ctx context.Context, version uint64, comp proto.CompressionMethod, handler func(*proto.BackupChunk) error, opts ...CallOption) error {
+	o := c.callOptions(opts)
+	req := &proto.BackupRequest{Version: version, Compression: o.compressionOr(comp)}
+	return c.interceptCall(ctx, o.callInfo(methodBackup), req, func(ctx context.Context) error {
+		return c.backup(ctx, req, handler, o)
+	})
+}
+
+func (c *Client) backup(ctx context.Context, req *proto.BackupRequest, handler func(*proto.BackupChunk) error, o *callOptions) error {
+	stream, l, err := callConn(c, ctx, methodBackup, o, req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (ClientStream, error) {
+		return streamOf(cli.Backup(ctx, req))
 	})
 	if err != nil {
 		return err
 	}
 
 	for {
-		chunk, err := stream.Recv()
+		chunk, err := recv[*proto.BackupChunk](stream)
 		if err == io.EOF {
+			l.release()
 			return nil
 		}
 		if err != nil {
+			if isConnectionError(err) {
+				l.conn.markBroken()
+			}
+			l.release()
 			return err
 		}
 		if err := handler(chunk); err != nil {
+			l.abandon()
 			return err
 		}
 	}
@@ -460,51 +1192,46 @@ func (p *BackupToS3Params) WithOnProgress(f func(*proto.BytesProgress) error) *B
 	return p
 }
 
-func (c *Client) BackupToS3(ctx context.Context, p *BackupToS3Params) (*proto.S3BackupFooter, error) {
+func (c *Client) BackupToS3(ctx context.Context, p *BackupToS3Params, opts ...CallOption) (*proto.S3BackupFooter, error) {
 	if p == nil || p.req == nil {
 		return nil, errors.New("request is required")
 	}
 
-	var (
-		stream proto.DRPCFlowDB_BackupToS3Client
-		conn   *conn
-		ft     *proto.S3BackupFooter
-	)
+	var ft *proto.S3BackupFooter
+	o := c.callOptions(opts)
+	err := c.interceptCall(ctx, o.callInfo(methodBackupToS3), p.req, func(ctx context.Context) (err error) {
+		ft, err = c.backupToS3(ctx, p, o)
+		return err
+	})
+	return ft, err
+}
+
+func (c *Client) backupToS3(ctx context.Context, p *BackupToS3Params, o *callOptions) (*proto.S3BackupFooter, error) {
+	var ft *proto.S3BackupFooter
 
-	var dialErr error
-	for i := 0; i < c.cfg.MaxRetriesPerCall; i++ {
-		w := c.pickConn()
-		cli, err := w.ensureClient(ctx)
-		if err != nil {
-			dialErr = err
-			time.Sleep(c.cfg.ReconnectInterval)
-			continue
-		}
-		stream, err = cli.BackupToS3(ctx, p.req)
-		if err == nil {
-			conn = w
-			break
-		}
-		if isConnectionError(err) {
-			w.markBroken()
-			dialErr = err
-			time.Sleep(c.cfg.ReconnectInterval)
-			continue
-		}
+	stream, l, err := callConn(c, ctx, methodBackupToS3, o, p.req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (ClientStream, error) {
+		return streamOf(cli.BackupToS3(ctx, p.req))
+	})
+	if err != nil {
 		return nil, err
 	}
-	if stream == nil {
-		return nil, dialErr
-	}
+	stopped := false
+	defer func() {
+		if stopped {
+			l.abandon()
+		} else {
+			l.release()
+		}
+	}()
 
 	for {
-		chunk, err := stream.Recv()
+		chunk, err := recv[*proto.S3BackupChunk](stream)
 		if err != nil {
 			if err == io.EOF {
 				return ft, nil
 			}
 			if isConnectionError(err) {
-				conn.markBroken()
+				l.conn.markBroken()
 			}
 			return nil, err
 		}
@@ -512,10 +1239,12 @@ func (c *Client) BackupToS3(ctx context.Context, p *BackupToS3Params) (*proto.S3
 		switch t := chunk.Chunk.(type) {
 		case *proto.S3BackupChunk_Header:
 			if err = p.onHeader(t.Header); err != nil {
+				stopped = true
 				return nil, err
 			}
 		case *proto.S3BackupChunk_Progress:
 			if err = p.onProgress(t.Progress); err != nil {
+				stopped = true
 				return nil, err
 			}
 		case *proto.S3BackupChunk_Footer:
@@ -552,51 +1281,48 @@ func (p *RestoreFromS3Params) WithOnProgress(f func(*proto.BytesProgress) error)
 	return p
 }
 
-func (c *Client) RestoreFromS3(ctx context.Context, p *RestoreFromS3Params) (*proto.S3RestoreFooter, error) {
+func (c *Client) RestoreFromS3(ctx context.Context, p *RestoreFromS3Params, opts ...CallOption) (*proto.S3RestoreFooter, error) {
 	if p == nil || p.req == nil {
 		return nil, errors.New("request is required")
 	}
 
-	var (
-		stream proto.DRPCFlowDB_RestoreFromS3Client
-		conn   *conn
-		ft     *proto.S3RestoreFooter
-	)
+	defer c.cache.invalidate("", "", minTime, maxTime)
 
-	var dialErr error
-	for i := 0; i < c.cfg.MaxRetriesPerCall; i++ {
-		w := c.pickConn()
-		cli, err := w.ensureClient(ctx)
-		if err != nil {
-			dialErr = err
-			time.Sleep(c.cfg.ReconnectInterval)
-			continue
-		}
-		stream, err = cli.RestoreFromS3(ctx, p.req)
-		if err == nil {
-			conn = w
-			break
-		}
-		if isConnectionError(err) {
-			w.markBroken()
-			dialErr = err
-			time.Sleep(c.cfg.ReconnectInterval)
-			continue
-		}
+	var ft *proto.S3RestoreFooter
+	o := c.callOptions(opts)
+	err := c.interceptCall(ctx, o.callInfo(methodRestoreFromS3), p.req, func(ctx context.Context) (err error) {
+		ft, err = c.restoreFromS3(ctx, p, o)
+		return err
+	})
+	return ft, err
+}
+
+func (c *Client) restoreFromS3(ctx context.Context, p *RestoreFromS3Params, o *callOptions) (*proto.S3RestoreFooter, error) {
+	var ft *proto.S3RestoreFooter
+
+	stream, l, err := callConn(c, ctx, methodRestoreFromS3, o, p.req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (ClientStream, error) {
+		return streamOf(cli.RestoreFromS3(ctx, p.req))
+	})
+	if err != nil {
 		return nil, err
 	}
-	if stream == nil {
-		return nil, dialErr
-	}
+	stopped := false
+	defer func() {
+		if stopped {
+			l.abandon()
+		} else {
+			l.release()
+		}
+	}()
 
 	for {
-		chunk, err := stream.Recv()
+		chunk, err := recv[*proto.S3RestoreChunk](stream)
 		if err != nil {
 			if err == io.EOF {
 				return ft, nil
 			}
 			if isConnectionError(err) {
-				conn.markBroken()
+				l.conn.markBroken()
 			}
 			return nil, err
 		}
@@ -604,10 +1330,12 @@ func (c *Client) RestoreFromS3(ctx context.Context, p *RestoreFromS3Params) (*pr
 		switch t := chunk.Chunk.(type) {
 		case *proto.S3RestoreChunk_Header:
 			if err = p.onHeader(t.Header); err != nil {
+				stopped = true
 				return nil, err
 			}
 		case *proto.S3RestoreChunk_Progress:
 			if err = p.onProgress(t.Progress); err != nil {
+				stopped = true
 				return nil, err
 			}
 		case *proto.S3RestoreChunk_Footer:
@@ -616,12 +1344,21 @@ func (c *Client) RestoreFromS3(ctx context.Context, p *RestoreFromS3Params) (*pr
 	}
 }
 
-func (c *Client) GetStats(ctx context.Context) (*proto.DBStats, error) {
-	return call(c, ctx, func(cli proto.DRPCFlowDBClient) (*proto.DBStats, error) {
-		return cli.GetStats(ctx, &proto.Empty{})
+func (c *Client) GetStats(ctx context.Context, opts ...CallOption) (*proto.DBStats, error) {
+	req := &proto.Empty{}
+	return call(c, ctx, methodGetStats, c.callOptions(opts), req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.DBStats, error) {
+		return cli.GetStats(ctx, req)
 	})
 }
 
+func (c *Client) Ping(ctx context.Context, opts ...CallOption) error {
+	req := &proto.Empty{}
+	_, err := call(c, ctx, methodPing, c.callOptions(opts), req, func(ctx context.Context, cli proto.DRPCFlowDBClient) (*proto.Empty, error) {
+		return cli.Ping(ctx, req)
+	})
+	return err
+}
+
 func Int64(i int64) *int64    { return &i }
 func Uint32(i uint32) *uint32 { return &i }
 func Uint64(i uint64) *uint64 { return &i }
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"storj.io/drpc/drpcerr"
+)
+
+var (
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// metadata key the server reads credentials from
+const AuthorizationKey = "authorization"
+
+// AuthError is returned by calls the server refused for their credentials.
+// It matches ErrUnauthenticated or ErrPermissionDenied with errors.Is.
+type AuthError struct {
+	Code proto.ErrorCode
+	// the server's message
+	Message string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Unwrap(), e.Message)
+}
+
+func (e *AuthError) Unwrap() error {
+	if e.Code == proto.ErrorCode_ErrorPermissionDenied {
+		return ErrPermissionDenied
+	}
+	return ErrUnauthenticated
+}
+
+// authError turns an error carrying one of the server's auth codes into an
+// *AuthError and returns any other error as is.
+func authError(err error) error {
+	switch code := proto.ErrorCode(drpcerr.Code(err)); code {
+	case proto.ErrorCode_ErrorUnauthenticated, proto.ErrorCode_ErrorPermissionDenied:
+		return &AuthError{Code: code, Message: err.Error()}
+	}
+	return err
+}
+
+// Credentials supply the metadata that authenticates a call. Metadata is
+// called before every attempt, so it may hand out a fresh token each time.
+type Credentials interface {
+	Metadata(ctx context.Context) (map[string]string, error)
+}
+
+// Refresher is implemented by Credentials that can drop a token the server
+// rejected. A unary call failing with ErrUnauthenticated calls Refresh and
+// is tried once more.
+type Refresher interface {
+	Refresh()
+}
+
+type staticCredentials map[string]string
+
+func (s staticCredentials) Metadata(context.Context) (map[string]string, error) {
+	return s, nil
+}
+
+// BearerToken sends token as "Bearer <token>".
+func BearerToken(token string) Credentials {
+	return staticCredentials{AuthorizationKey: "Bearer " + token}
+}
+
+// APIKey sends key as "ApiKey <key>".
+func APIKey(key string) Credentials {
+	return staticCredentials{AuthorizationKey: "ApiKey " + key}
+}
+
+// TokenSource fetches a bearer token and the time it expires (zero = never).
+type TokenSource func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// tokens are fetched again this long before they expire
+const tokenExpiryMargin = 30 * time.Second
+
+// RefreshingToken sends bearer tokens from src, fetching a new one when the
+// current one is about to expire or was rejected by the server. Concurrent
+// calls share a single fetch.
+func RefreshingToken(src TokenSource) Credentials {
+	return &refreshingToken{src: src}
+}
+
+type refreshingToken struct {
+	src TokenSource
+
+	mu     sync.Mutex
+	md     map[string]string
+	expiry time.Time
+	// closed once the fetch in progress ends, nil when there is none
+	fetching chan struct{}
+}
+
+func (r *refreshingToken) Metadata(ctx context.Context) (map[string]string, error) {
+	for {
+		r.mu.Lock()
+		if r.md != nil && (r.expiry.IsZero() || time.Until(r.expiry) > tokenExpiryMargin) {
+			md := r.md
+			r.mu.Unlock()
+			return md, nil
+		}
+		wait := r.fetching
+		if wait == nil {
+			r.fetching = make(chan struct{})
+			r.mu.Unlock()
+			return r.fetch(ctx)
+		}
+		r.mu.Unlock()
+
+		// another call is fetching: use its token, or fetch again if it
+		// failed
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// fetch gets a token from src for the calls waiting on r.fetching.
+func (r *refreshingToken) fetch(ctx context.Context) (map[string]string, error) {
+	token, expiry, err := r.src(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	close(r.fetching)
+	r.fetching = nil
+	if err != nil {
+		return nil, err
+	}
+	r.md = map[string]string{AuthorizationKey: "Bearer " + token}
+	r.expiry = expiry
+	return r.md, nil
+}
+
+func (r *refreshingToken) Refresh() {
+	r.mu.Lock()
+	r.md = nil
+	r.mu.Unlock()
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/memserver"
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+var adminRole = &proto.Role{Name: "admin", Grants: []*proto.TableGrant{{
+	Table:       memserver.AllTables,
+	Permissions: []proto.Permission{proto.Permission_PermissionAdmin},
+}}}
+
+func TestStaticCredentials(t *testing.T) {
+	s := newTestServer(t)
+	s.AddCredential("secret", adminRole)
+
+	for _, creds := range []Credentials{BearerToken("secret"), APIKey("secret")} {
+		c := dialTest(t, s, Config{Credentials: creds})
+		if _, err := c.ListTables(context.Background()); err != nil {
+			t.Fatalf("%v: %v", creds, err)
+		}
+	}
+
+	for _, creds := range []Credentials{nil, BearerToken("wrong"), staticCredentials{AuthorizationKey: "Basic secret"}} {
+		c := dialTest(t, s, Config{Credentials: creds})
+		_, err := c.ListTables(context.Background())
+		var ae *AuthError
+		if !errors.As(err, &ae) || ae.Code != proto.ErrorCode_ErrorUnauthenticated || !errors.Is(err, ErrUnauthenticated) {
+			t.Fatalf("%v: got %v, want an unauthenticated AuthError", creds, err)
+		}
+	}
+}
+
+func TestPermissionDenied(t *testing.T) {
+	s := newTestServer(t)
+	s.AddCredential("admin", adminRole)
+	s.AddCredential("reader", &proto.Role{Name: "reader", Grants: []*proto.TableGrant{{
+		Table:       "t",
+		Permissions: []proto.Permission{proto.Permission_PermissionRead},
+	}}})
+	seed(t, dialTest(t, s, Config{Credentials: BearerToken("admin")}), "t", "", 10)
+
+	attempts := 0
+	c := dialTest(t, s, Config{
+		Credentials: BearerToken("reader"),
+		UnaryInterceptors: []UnaryInterceptor{func(ctx context.Context, info *CallInfo, req any, invoke UnaryInvoker) (any, error) {
+			attempts++
+			return invoke(ctx)
+		}},
+	})
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts = 0
+	_, err := c.Insert(context.Background(), &proto.InsertRequest{TableName: "t", Rows: lateRow})
+	var ae *AuthError
+	if !errors.As(err, &ae) || ae.Code != proto.ErrorCode_ErrorPermissionDenied || !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("got %v, want a permission denied AuthError", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("denied call made %d attempts", attempts)
+	}
+}
+
+func TestRefreshingTokenRetries(t *testing.T) {
+	s := newTestServer(t)
+	s.AddCredential("first", adminRole)
+
+	var fetches atomic.Int32
+	token := "first"
+	c := dialTest(t, s, Config{Credentials: RefreshingToken(func(context.Context) (string, time.Time, error) {
+		fetches.Add(1)
+		return token, time.Time{}, nil
+	})})
+	for range 2 {
+		if _, err := c.ListTables(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Fatalf("%d fetches of a token that doesn't expire", n)
+	}
+
+	// the server revokes the token; the call fetches a new one and retries
+	s.AddCredential("second", adminRole)
+	s.RemoveCredential("first")
+	token = "second"
+	if _, err := c.ListTables(context.Background()); err != nil {
+		t.Fatalf("call not retried with a fresh token: %v", err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Fatalf("%d fetches", n)
+	}
+
+	// when the fresh token is rejected too the call fails after one retry;
+	// "third" keeps auth on
+	s.AddCredential("third", adminRole)
+	s.RemoveCredential("second")
+	_, err := c.ListTables(context.Background())
+	if !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("got %v, want ErrUnauthenticated", err)
+	}
+	if n := fetches.Load(); n != 3 {
+		t.Fatalf("%d fetches", n)
+	}
+}
+
+func TestRefreshingTokenExpiry(t *testing.T) {
+	var fetches atomic.Int32
+	creds := RefreshingToken(func(context.Context) (string, time.Time, error) {
+		fetches.Add(1)
+		// inside the expiry margin, so every call fetches again
+		return "t", time.Now().Add(tokenExpiryMargin / 2), nil
+	})
+	for range 3 {
+		md, err := creds.Metadata(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if md[AuthorizationKey] != "Bearer t" {
+			t.Fatalf("metadata %v", md)
+		}
+	}
+	if n := fetches.Load(); n != 3 {
+		t.Fatalf("%d fetches of an expiring token", n)
+	}
+}
+
+func TestRefreshingTokenSharedFetch(t *testing.T) {
+	var fetches atomic.Int32
+	unblock := make(chan struct{})
+	creds := RefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		fetches.Add(1)
+		<-unblock
+		return "t", time.Time{}, nil
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := creds.Metadata(context.Background())
+			errs <- err
+		}()
+	}
+	eventually(t, func() bool { return fetches.Load() == 1 }, "no fetch started")
+
+	// a caller giving up doesn't wait for the fetch
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := creds.Metadata(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v while a fetch is running, want the deadline", err)
+	}
+
+	close(unblock)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Fatalf("%d fetches, want one shared", n)
+	}
+}
+
+func TestRefreshingTokenFetchFails(t *testing.T) {
+	fail := errors.New("token endpoint down")
+	var fetches atomic.Int32
+	creds := RefreshingToken(func(context.Context) (string, time.Time, error) {
+		if fetches.Add(1) == 1 {
+			return "", time.Time{}, fail
+		}
+		return "t", time.Time{}, nil
+	})
+	if _, err := creds.Metadata(context.Background()); !errors.Is(err, fail) {
+		t.Fatalf("got %v, want the source's error", err)
+	}
+	if _, err := creds.Metadata(context.Background()); err != nil {
+		t.Fatalf("not fetched again after a failure: %v", err)
+	}
+}
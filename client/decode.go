@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// method AutoCompression picks for links leaving the machine
+const remoteCompression = proto.CompressionMethod_CompressionLZ4
+
+// decodeRows replaces the data of rows sent with method by the
+// decompressed data, and returns the payload size before and after.
+func (c *Client) decodeRows(rows []*proto.Row, method proto.CompressionMethod) (compressed, uncompressed uint64, err error) {
+	comp := c.comp.Get(int32(method))
+	for _, r := range rows {
+		compressed += uint64(len(r.Data))
+		if method != proto.CompressionMethod_CompressionNone {
+			data, err := comp.Decompress(r.Data)
+			if err != nil {
+				return 0, 0, fmt.Errorf("decompress row: %w", err)
+			}
+			r.Data = data
+		}
+		uncompressed += uint64(len(r.Data))
+	}
+	return compressed, uncompressed, nil
+}
+
+// wireBytes returns the bytes of a response as received, and as they would
+// have been with the payload, sent as sent bytes, taking raw bytes.
+func wireBytes(received, sent, raw uint64) (compressed, uncompressed uint64) {
+	if raw >= sent {
+		return received, received + raw - sent
+	}
+	return received, received - min(received, sent-raw)
+}
+
+// queryCompression returns req with the compression chosen by
+// WithCompression, copying it rather than changing the caller's request.
+func (c *Client) queryCompression(req *proto.QueryRequest, o *callOptions) *proto.QueryRequest {
+	if o.compression == nil || *o.compression == req.Compression {
+		return req
+	}
+	req = req.CloneVT()
+	req.Compression = *o.compression
+	return req
+}
+
+// autoCompression returns req with the compression AutoCompression picks
+// for the conn the attempt in ctx runs over, when req asks for none and
+// the call didn't choose one.
+func (c *Client) autoCompression(ctx context.Context, req *proto.QueryRequest, o *callOptions) *proto.QueryRequest {
+	if !c.cfg.AutoCompression || !c.cfg.DecompressPayloads || o.compression != nil ||
+		req.Compression != proto.CompressionMethod_CompressionNone {
+		return req
+	}
+	w, _ := ctx.Value(connKey{}).(*conn)
+	if w == nil {
+		return req
+	}
+	w.mu.RLock()
+	method := w.link
+	w.mu.RUnlock()
+	if method == proto.CompressionMethod_CompressionNone {
+		return req
+	}
+	req = req.CloneVT()
+	req.Compression = method
+	return req
+}
+
+// connKey holds the conn an attempt runs over in its context.
+type connKey struct{}
+
+// linkCompression picks the compression for a transport: none when it
+// stays on this machine, where it only costs CPU, and remoteCompression
+// otherwise.
+func linkCompression(nc net.Conn) proto.CompressionMethod {
+	if isLocal(nc.LocalAddr(), nc.RemoteAddr()) {
+		return proto.CompressionMethod_CompressionNone
+	}
+	return remoteCompression
+}
+
+// isLocal reports whether a transport between local and remote stays on
+// this machine: a socket, a pipe, or loopback or the machine's own address
+// at both ends.
+func isLocal(local, remote net.Addr) bool {
+	if remote == nil {
+		return true
+	}
+	switch remote.Network() {
+	case "unix", "unixpacket", "unixgram", "pipe":
+		return true
+	}
+	rip, lip := addrIP(remote), addrIP(local)
+	if rip == nil {
+		return false
+	}
+	return rip.IsLoopback() || lip != nil && rip.Equal(lip)
+}
+
+func addrIP(a net.Addr) net.IP {
+	switch a := a.(type) {
+	case *net.TCPAddr:
+		return a.IP
+	case *net.UDPAddr:
+		return a.IP
+	case nil:
+		return nil
+	}
+	host, _, err := net.SplitHostPort(a.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
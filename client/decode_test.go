@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+func TestRowsStayCompressedByDefault(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{})
+	seed(t, c, "t", "", 10)
+
+	lz4 := WithCompression(proto.CompressionMethod_CompressionLZ4)
+	resp, err := c.Query(context.Background(), rangeQuery("t", 0, 10), lz4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Compression != proto.CompressionMethod_CompressionLZ4 || string(resp.Rows[0].Data) == string(rowData(0)) {
+		t.Fatalf("rows decompressed without DecompressPayloads, compression %v", resp.Compression)
+	}
+	rows, _, err := streamAll(t, c, NewStreamQueryParams().WithRequest(rangeQuery("t", 0, 10)), lz4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rows[0].Data) == string(rowData(0)) {
+		t.Fatal("streamed rows decompressed without DecompressPayloads")
+	}
+}
+
+func TestDecompressPayloads(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{DecompressPayloads: true})
+	seed(t, c, "t", "", 100)
+
+	for _, m := range []proto.CompressionMethod{proto.CompressionMethod_CompressionNone, proto.CompressionMethod_CompressionLZ4} {
+		resp, err := c.Query(context.Background(), rangeQuery("t", 0, 100), WithCompression(m))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRows(t, resp.Rows, 0, 100, false)
+
+		rows, sresp, err := streamAll(t, c, NewStreamQueryParams().WithRequest(rangeQuery("t", 0, 100)), WithCompression(m))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRows(t, rows, 0, 100, false)
+
+		// the bytes received carry the rows and their framing
+		payload := uint64(100 * len(rowData(0)))
+		for _, r := range []*proto.QueryResponse{resp, sresp} {
+			switch {
+			case r.UncompressedBytes <= payload:
+				t.Fatalf("%v: %d uncompressed bytes for %d bytes of rows", m, r.UncompressedBytes, payload)
+			case m == proto.CompressionMethod_CompressionNone && r.CompressedBytes != r.UncompressedBytes,
+				m == proto.CompressionMethod_CompressionLZ4 && r.CompressedBytes >= payload:
+				t.Fatalf("%v: %d bytes received, %d uncompressed", m, r.CompressedBytes, r.UncompressedBytes)
+			}
+		}
+	}
+}
+
+// remoteConn makes a transport look like it leaves the machine.
+type remoteConn struct {
+	net.Conn
+}
+
+func (remoteConn) LocalAddr() net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50000}
+}
+
+func (remoteConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 7000}
+}
+
+func TestAutoCompression(t *testing.T) {
+	s := newTestServer(t)
+	local := dialTest(t, s, Config{DecompressPayloads: true, AutoCompression: true})
+	seed(t, local, "t", "", 10)
+
+	remote := dialTest(t, s, Config{
+		Address:            "remote",
+		DecompressPayloads: true,
+		AutoCompression:    true,
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			nc, err := s.Dial(ctx, network, addr)
+			return remoteConn{nc}, err
+		},
+	})
+	raw := dialTest(t, s, Config{AutoCompression: true, Dialer: remote.cfg.Dialer, Address: "remote"})
+
+	for _, tc := range []struct {
+		c    *Client
+		want proto.CompressionMethod
+	}{
+		{local, proto.CompressionMethod_CompressionNone},
+		{remote, remoteCompression},
+		// rows that reach the caller as sent aren't compressed unasked
+		{raw, proto.CompressionMethod_CompressionNone},
+	} {
+		resp, err := tc.c.Query(context.Background(), rangeQuery("t", 0, 10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Compression != tc.want {
+			t.Fatalf("%s: compression %v, want %v", tc.c.cfg.Address, resp.Compression, tc.want)
+		}
+		checkRows(t, resp.Rows, 0, 10, false)
+	}
+}
+
+func TestIsLocal(t *testing.T) {
+	tcp := func(ip string) net.Addr { return &net.TCPAddr{IP: net.ParseIP(ip), Port: 7000} }
+	pipe, _ := net.Pipe()
+	defer pipe.Close()
+
+	for _, tc := range []struct {
+		local, remote net.Addr
+		want          bool
+	}{
+		{nil, &net.UnixAddr{Name: "/tmp/flowdb.sock", Net: "unix"}, true},
+		{pipe.LocalAddr(), pipe.RemoteAddr(), true},
+		{tcp("127.0.0.1"), tcp("127.0.0.1"), true},
+		{tcp("::1"), tcp("::1"), true},
+		{tcp("10.0.0.5"), tcp("10.0.0.5"), true},
+		{tcp("10.0.0.5"), tcp("10.0.0.6"), false},
+	} {
+		if got := isLocal(tc.local, tc.remote); got != tc.want {
+			t.Errorf("isLocal(%v, %v) = %v", tc.local, tc.remote, got)
+		}
+	}
+}
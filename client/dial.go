@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"sync/atomic"
+)
+
+// splitAddress returns the network and address of an endpoint, taking the
+// network from a tcp://, tcp4://, tcp6:// or unix:// scheme when present.
+func splitAddress(cfg *Config, addr string) (network, address string) {
+	scheme, rest, ok := strings.Cut(addr, "://")
+	if !ok {
+		return cfg.Network, addr
+	}
+	switch scheme {
+	case "tcp", "tcp4", "tcp6", "unix", "unixpacket":
+		return scheme, rest
+	}
+	return cfg.Network, addr
+}
+
+// dialEndpoint opens a transport to addr, with TLS when configured. Bytes
+// read off it are added to received unless that is nil.
+func dialEndpoint(ctx context.Context, cfg *Config, addr string, received *atomic.Uint64) (net.Conn, error) {
+	network, address := splitAddress(cfg, addr)
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	var nc net.Conn
+	var err error
+	if cfg.Dialer != nil {
+		nc, err = cfg.Dialer(ctx, network, address)
+	} else {
+		nc, err = (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if received != nil {
+		nc = &countingConn{Conn: nc, received: received}
+	}
+	if cfg.TLSConfig == nil {
+		return nc, nil
+	}
+
+	tc := cfg.TLSConfig.Clone()
+	if tc.ServerName == "" {
+		tc.ServerName = serverName(network, address)
+	}
+	if verify := tc.VerifyConnection; verify != nil {
+		// an IP isn't sent as SNI, so the verifier is told the name here
+		name := tc.ServerName
+		tc.VerifyConnection = func(cs tls.ConnectionState) error {
+			cs.ServerName = name
+			return verify(cs)
+		}
+	}
+	conn := tls.Client(nc, tc)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		_ = nc.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// serverName is the name a TLS certificate is verified against when
+// TLSConfig.ServerName is unset: the host of a TCP address, or "localhost"
+// for sockets.
+func serverName(network, address string) string {
+	if strings.HasPrefix(network, "unix") {
+		return "localhost"
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+// countingConn counts the bytes read off a transport.
+type countingConn struct {
+	net.Conn
+	received *atomic.Uint64
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.received.Add(uint64(n))
+	return n, err
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+func TestSplitAddress(t *testing.T) {
+	cfg := &Config{Network: "tcp"}
+	for _, tc := range []struct {
+		addr, network, address string
+	}{
+		{"localhost:7777", "tcp", "localhost:7777"},
+		{"tcp://localhost:7777", "tcp", "localhost:7777"},
+		{"tcp4://127.0.0.1:7777", "tcp4", "127.0.0.1:7777"},
+		{"tcp6://[::1]:7777", "tcp6", "[::1]:7777"},
+		{"unix:///run/flowdb.sock", "unix", "/run/flowdb.sock"},
+		{"unixpacket:///run/flowdb.sock", "unixpacket", "/run/flowdb.sock"},
+		// unknown schemes are left to the dialer
+		{"http://localhost:7777", "tcp", "http://localhost:7777"},
+	} {
+		network, address := splitAddress(cfg, tc.addr)
+		if network != tc.network || address != tc.address {
+			t.Errorf("splitAddress(%q) = %q, %q", tc.addr, network, address)
+		}
+	}
+}
+
+func TestDialUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flowdb.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestServer(t)
+	go func() { _ = s.Serve(context.Background(), lis) }()
+
+	c := dialTest(t, s, Config{Address: "unix://" + path, PoolSize: 2})
+	if _, err := c.CreateTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	for _, ep := range c.Endpoints() {
+		if ep.Conns != 2 {
+			t.Fatalf("endpoint %+v", ep)
+		}
+	}
+}
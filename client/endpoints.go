@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// endpoint is one server address the pool spreads connections across.
+// After Config.UnhealthyThreshold consecutive failures it is marked
+// unhealthy: calls avoid it and the health loop probes it until it answers.
+type endpoint struct {
+	addr string
+
+	mu       sync.Mutex
+	healthy  bool
+	failures int
+	lastErr  error
+	since    time.Time
+
+	breaker *breaker
+}
+
+func newEndpoint(addr string) *endpoint {
+	return &endpoint{addr: addr, healthy: true, since: time.Now()}
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.healthy
+}
+
+func (e *endpoint) success() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failures = 0
+	if !e.healthy {
+		e.healthy = true
+		e.since = time.Now()
+	}
+}
+
+// failure records err and reports whether it made the endpoint unhealthy.
+func (e *endpoint) failure(err error, threshold int) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failures++
+	e.lastErr = err
+	if e.healthy && e.failures >= threshold {
+		e.healthy = false
+		e.since = time.Now()
+		return true
+	}
+	return false
+}
+
+type EndpointState struct {
+	Address string
+	Healthy bool
+	// time of the last health change
+	Since time.Time
+	// pooled connections currently connected to this endpoint
+	Conns int
+	// pooled connections that prefer this endpoint
+	HomeConns           int
+	ConsecutiveFailures int
+	LastError           error
+	Breaker             BreakerState
+}
+
+// Endpoints reports the health and pool share of every endpoint.
+func (c *Client) Endpoints() []EndpointState {
+	out := make([]EndpointState, len(c.endpoints))
+	idx := make(map[*endpoint]int, len(c.endpoints))
+
+	for i, e := range c.endpoints {
+		idx[e] = i
+		e.mu.Lock()
+		out[i] = EndpointState{
+			Address:             e.addr,
+			Healthy:             e.healthy,
+			Since:               e.since,
+			ConsecutiveFailures: e.failures,
+			LastError:           e.lastErr,
+		}
+		e.mu.Unlock()
+		out[i].Breaker = e.breaker.current()
+	}
+
+	for _, w := range c.conns() {
+		out[idx[w.home]].HomeConns++
+		if ep := w.connectedTo(); ep != nil {
+			out[idx[ep]].Conns++
+		}
+	}
+	return out
+}
+
+// reportFailure records a failed dial or call against e and, once e turns
+// unhealthy, moves the pooled conns on it elsewhere.
+func (c *Client) reportFailure(e *endpoint, err error) {
+	if e == nil || !e.failure(err, c.cfg.UnhealthyThreshold) {
+		return
+	}
+	c.moveOff(e)
+}
+
+// moveOff redials the pooled conns connected to e on other endpoints: idle
+// ones now, checked-out ones once their call returns them, so calls still
+// running on e aren't cut off.
+func (c *Client) moveOff(e *endpoint) {
+	for _, p := range []*connPool{c.pool, c.streamPool} {
+		if p == nil {
+			continue
+		}
+		for _, w := range p.all() {
+			if w.connectedTo() != e {
+				continue
+			}
+			// flagged first, so a conn returned meanwhile is still redialed
+			w.leave(e)
+			if p.take(w) {
+				p.put(w)
+			}
+		}
+	}
+}
+
+func (c *Client) healthLoop() {
+	defer c.wg.Done()
+
+	t := time.NewTicker(c.cfg.HealthCheckInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		for _, e := range c.endpoints {
+			if e.isHealthy() {
+				continue
+			}
+			if err := c.probeEndpoint(e); err != nil {
+				e.mu.Lock()
+				e.lastErr = err
+				e.mu.Unlock()
+				continue
+			}
+			e.success()
+		}
+	}
+}
+
+// probeEndpoint checks that e accepts connections.
+func (c *Client) probeEndpoint(e *endpoint) error {
+	ctx, cancel := context.WithTimeout(c.ctx, c.cfg.Timeout)
+	defer cancel()
+
+	nc, err := dialEndpoint(ctx, &c.cfg, e.addr, nil)
+	if err != nil {
+		return err
+	}
+	return nc.Close()
+}
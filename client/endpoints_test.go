@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nonhumantrades/flowdb-go/memserver"
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+func TestReportFailureSparesBusyConns(t *testing.T) {
+	servers := map[string]*memserver.Server{"a": newTestServer(t), "b": newTestServer(t)}
+	c := dialServers(t, []string{"a", "b"}, servers, Config{PoolSize: 4, UnhealthyThreshold: 1})
+	a := c.endpoints[0]
+	conns := c.pool.all()
+	// conns 0 and 2 are homed on a; 0 is running a call
+	busy, idle := conns[0], conns[2]
+	if busy.connectedTo() != a || idle.connectedTo() != a {
+		t.Fatal("conns not connected to their home endpoint")
+	}
+	if !c.pool.take(busy) {
+		t.Fatal("conn 0 not idle")
+	}
+
+	c.reportFailure(a, errors.New("down"))
+	if a.isHealthy() {
+		t.Fatal("endpoint still healthy")
+	}
+	if idle.connectedTo() != nil {
+		t.Fatal("idle conn left on the unhealthy endpoint")
+	}
+	if busy.connectedTo() != a {
+		t.Fatal("busy conn cut off")
+	}
+	busy.mu.RLock()
+	cli := busy.client
+	busy.mu.RUnlock()
+	if _, err := cli.Ping(context.Background(), &proto.Empty{}); err != nil {
+		t.Fatalf("call on the busy conn: %v", err)
+	}
+
+	// released, it moves too
+	c.pool.put(busy)
+	if busy.connectedTo() != nil {
+		t.Fatal("released conn left on the unhealthy endpoint")
+	}
+	for _, w := range []*conn{busy, idle} {
+		if _, err := w.ensureClient(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if ep := w.connectedTo(); ep != c.endpoints[1] {
+			t.Fatalf("conn %d redialed to %v", w.id, ep.addr)
+		}
+	}
+}
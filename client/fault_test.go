@@ -0,0 +1,335 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/faultproxy"
+	"github.com/nonhumantrades/flowdb-go/memserver"
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// faultEnv is a memserver on loopback TCP behind a fault proxy.
+type faultEnv struct {
+	srv   *memserver.Server
+	proxy *faultproxy.Proxy
+}
+
+func newFaultEnv(t *testing.T) *faultEnv {
+	t.Helper()
+	s := newTestServer(t)
+	addr, err := s.Listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := faultproxy.New(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = p.Close() })
+	return &faultEnv{srv: s, proxy: p}
+}
+
+// dial connects through the proxy. cfg.Backoff defaults to healing the
+// proxy before the first retry, so a call only succeeds under a lasting
+// fault if it was retried.
+func (e *faultEnv) dial(t *testing.T, cfg Config) *Client {
+	t.Helper()
+	cfg.Address = e.proxy.Addr()
+	cfg.Dialer = e.proxy.Dial
+	if cfg.PoolSize == 0 {
+		cfg.PoolSize = 2
+	}
+	if cfg.Backoff == nil {
+		cfg.Backoff = backoffFunc(func(int) (time.Duration, bool) {
+			_ = e.proxy.Heal()
+			return 0, true
+		})
+	}
+	return dialTest(t, e.srv, cfg)
+}
+
+// fault applies f to the connections of c from now on, dropping the open
+// ones and waiting for c to notice.
+func (e *faultEnv) fault(t *testing.T, c *Client, f faultproxy.Faults) {
+	t.Helper()
+	if err := e.proxy.SetFaults(f); err != nil {
+		t.Fatal(err)
+	}
+	e.proxy.DropConns()
+	eventually(t, func() bool {
+		for _, w := range c.conns() {
+			w.mu.RLock()
+			dc := w.conn
+			w.mu.RUnlock()
+			if dc != nil && !isClosed(dc) {
+				return false
+			}
+		}
+		return true
+	}, "dropped conns still open")
+}
+
+func TestFaultDialRefused(t *testing.T) {
+	e := newFaultEnv(t)
+	_ = e.proxy.SetFaults(faultproxy.Faults{RefuseDial: faultproxy.Always})
+	_, err := Dial(context.Background(), Config{Address: e.proxy.Addr(), Dialer: e.proxy.Dial, PoolSize: 2})
+	if !errors.Is(err, ErrNoConnection) || !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("got %v, want ErrNoConnection caused by connection refused", err)
+	}
+
+	// not listening at all
+	_ = e.proxy.SetFaults(faultproxy.Faults{Refuse: true})
+	_, err = Dial(context.Background(), Config{Address: e.proxy.Addr(), PoolSize: 2})
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("got %v, want connection refused", err)
+	}
+}
+
+func TestFaultWaitForReady(t *testing.T) {
+	e := newFaultEnv(t)
+	_ = e.proxy.SetFaults(faultproxy.Faults{RefuseDial: faultproxy.FirstN(3)})
+	c := e.dial(t, Config{WaitForReady: 2, Backoff: noWait})
+	if st := e.proxy.Stats(); st.Refused != 3 {
+		t.Fatalf("refused %d dials, want 3", st.Refused)
+	}
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// unaryFaults break the first call made on a conn.
+var unaryFaults = map[string]faultproxy.Faults{
+	"truncate response": {TruncateAfterBytes: 5},
+	"truncate request":  {Direction: faultproxy.ClientToServer, TruncateAfterBytes: 5},
+	"reset request":     {Direction: faultproxy.ClientToServer, ResetAfterBytes: 1},
+	"drop request":      {Direction: faultproxy.ClientToServer, DropAfterBytes: 1},
+}
+
+func TestFaultUnaryRetried(t *testing.T) {
+	for name, f := range unaryFaults {
+		t.Run(name, func(t *testing.T) {
+			e := newFaultEnv(t)
+			c := e.dial(t, Config{})
+			seed(t, c, "t", "", 10)
+
+			e.fault(t, c, f)
+			tbl, err := c.GetTable(context.Background(), "t")
+			if err != nil {
+				t.Fatalf("idempotent call not retried: %v", err)
+			}
+			if tbl.RowCount != 10 {
+				t.Fatalf("row count %d", tbl.RowCount)
+			}
+		})
+	}
+}
+
+func TestFaultUnaryNotRetried(t *testing.T) {
+	for name, f := range unaryFaults {
+		t.Run(name, func(t *testing.T) {
+			e := newFaultEnv(t)
+			c := e.dial(t, Config{})
+			seed(t, c, "t", "", 10)
+
+			e.fault(t, c, f)
+			_, err := c.Delete(context.Background(), &proto.DeleteRequest{TableName: "t"})
+			if !IsConnectionError(err) {
+				t.Fatalf("non-idempotent call: got %v, want the connection error", err)
+			}
+			// the next call gets a working conn
+			_ = e.proxy.Heal()
+			if _, err := c.GetTable(context.Background(), "t"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestFaultLatency(t *testing.T) {
+	e := newFaultEnv(t)
+	c := e.dial(t, Config{Backoff: noWait})
+	_ = e.proxy.SetFaults(faultproxy.Faults{Latency: 100 * time.Millisecond})
+
+	_, err := c.ListTables(context.Background(), WithTimeout(20*time.Millisecond), WithMaxRetries(2))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	if _, err := c.ListTables(context.Background()); err != nil {
+		t.Fatalf("slow call without a short timeout: %v", err)
+	}
+}
+
+// streamFaults cut a stream once part of it was received.
+var streamFaults = map[string]faultproxy.Faults{
+	"drop":     {DropAfterBytes: 64 << 10},
+	"reset":    {ResetAfterBytes: 64 << 10},
+	"truncate": {TruncateAfterBytes: 64 << 10},
+}
+
+func TestFaultStreamQuery(t *testing.T) {
+	for name, f := range streamFaults {
+		t.Run(name, func(t *testing.T) {
+			e := newFaultEnv(t)
+			c := e.dial(t, Config{})
+			seed(t, c, "t", "", 5000)
+			req := &proto.QueryRequest{TableName: "t", StreamOptions: &proto.StreamOptions{RowsPerChunk: Uint32(100)}}
+
+			e.fault(t, c, f)
+			rows, _, err := streamAll(t, c, NewStreamQueryParams().WithRequest(req))
+			if !IsConnectionError(err) {
+				t.Fatalf("got %v, want a connection error", err)
+			}
+			if len(rows) == 0 || len(rows) >= 5000 {
+				t.Fatalf("%d rows delivered before the fault", len(rows))
+			}
+
+			// every resume is cut again, but gets further
+			e.fault(t, c, f)
+			rows, resp, err := streamAll(t, c, NewStreamQueryParams().WithRequest(req).WithResume(true), WithMaxRetries(100))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkRows(t, rows, 0, 5000, false)
+			if resp.Count != 5000 {
+				t.Fatalf("count %d", resp.Count)
+			}
+		})
+	}
+}
+
+func TestFaultBackup(t *testing.T) {
+	for name, f := range streamFaults {
+		t.Run(name, func(t *testing.T) {
+			e := newFaultEnv(t)
+			c := e.dial(t, Config{})
+			seed(t, c, "t", "", 5000)
+
+			e.fault(t, c, f)
+			var got int
+			err := c.Backup(context.Background(), 0, proto.CompressionMethod_CompressionNone, func(b *proto.BackupChunk) error {
+				got += len(b.Data)
+				return nil
+			})
+			if !IsConnectionError(err) {
+				t.Fatalf("got %v, want a connection error", err)
+			}
+
+			_ = e.proxy.Heal()
+			var want int
+			err = c.Backup(context.Background(), 0, proto.CompressionMethod_CompressionNone, func(b *proto.BackupChunk) error {
+				want += len(b.Data)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got >= want {
+				t.Fatalf("cut backup delivered %d of %d bytes", got, want)
+			}
+		})
+	}
+}
+
+// s3Faults cut the short S3 streams before they finish: a whole stream
+// fits in one read, so drops and resets hit the request.
+var s3Faults = map[string]faultproxy.Faults{
+	"drop":     {Direction: faultproxy.ClientToServer, DropAfterBytes: 1},
+	"reset":    {Direction: faultproxy.ClientToServer, ResetAfterBytes: 1},
+	"truncate": {TruncateAfterBytes: 20},
+}
+
+func TestFaultBackupToS3(t *testing.T) {
+	for name, f := range s3Faults {
+		t.Run(name, func(t *testing.T) {
+			e := newFaultEnv(t)
+			c := e.dial(t, Config{})
+			seed(t, c, "t", "", 10)
+			req := &proto.S3BackupRequest{S3Config: &proto.S3Config{Bucket: "b"}}
+
+			e.fault(t, c, f)
+			_, err := c.BackupToS3(context.Background(), NewBackupToS3Params().WithRequest(req))
+			if !IsConnectionError(err) {
+				t.Fatalf("got %v, want a connection error", err)
+			}
+
+			_ = e.proxy.Heal()
+			ft, err := c.BackupToS3(context.Background(), NewBackupToS3Params().WithRequest(req))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ft.GetObjectKey() == "" {
+				t.Fatal("no footer")
+			}
+		})
+	}
+}
+
+func TestFaultRestoreFromS3(t *testing.T) {
+	for name, f := range s3Faults {
+		t.Run(name, func(t *testing.T) {
+			e := newFaultEnv(t)
+			c := e.dial(t, Config{})
+			seed(t, c, "t", "", 10)
+			cfg := &proto.S3Config{Bucket: "b"}
+			if _, err := c.BackupToS3(context.Background(), NewBackupToS3Params().WithRequest(&proto.S3BackupRequest{S3Config: cfg})); err != nil {
+				t.Fatal(err)
+			}
+			req := &proto.S3RestoreRequest{S3Config: cfg}
+
+			e.fault(t, c, f)
+			_, err := c.RestoreFromS3(context.Background(), NewRestoreFromS3Params().WithRequest(req))
+			if !IsConnectionError(err) {
+				t.Fatalf("got %v, want a connection error", err)
+			}
+
+			_ = e.proxy.Heal()
+			if _, err := c.RestoreFromS3(context.Background(), NewRestoreFromS3Params().WithRequest(req)); err != nil {
+				t.Fatal(err)
+			}
+			tbl, err := c.GetTable(context.Background(), "t")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tbl.RowCount != 10 {
+				t.Fatalf("restored %d rows", tbl.RowCount)
+			}
+		})
+	}
+}
+
+func TestFaultInsert(t *testing.T) {
+	rows := []*proto.Row{{Timestamp: ts(100), Data: []byte("a")}, {Timestamp: ts(101), Data: []byte("b")}}
+	// the server applies the insert, but its answer is lost
+	lost := faultproxy.Faults{TruncateAfterBytes: 5}
+
+	for _, idempotent := range []bool{false, true} {
+		e := newFaultEnv(t)
+		c := e.dial(t, Config{IdempotentInserts: idempotent, MaxRetriesPerCall: 4})
+		seed(t, c, "t", "", 10)
+		before, _ := e.srv.GetStats(context.Background(), &proto.Empty{})
+
+		e.fault(t, c, lost)
+		_, err := c.Insert(context.Background(), &proto.InsertRequest{TableName: "t", Rows: rows})
+		if idempotent && err != nil {
+			t.Fatalf("IdempotentInserts: insert not retried: %v", err)
+		}
+		if !idempotent && !IsConnectionError(err) {
+			t.Fatalf("insert: got %v, want the connection error", err)
+		}
+
+		after, _ := e.srv.GetStats(context.Background(), &proto.Empty{})
+		sent, written := after.InsertRequests-before.InsertRequests, after.BytesWritten-before.BytesWritten
+		wantSent := uint64(1)
+		if idempotent {
+			wantSent = 2
+		}
+		// a retry is answered from the idempotency key, not written again
+		if sent != wantSent || written != 2 {
+			t.Fatalf("IdempotentInserts %v: %d requests wrote %d bytes, want %d requests writing 2", idempotent, sent, written, wantSent)
+		}
+	}
+}
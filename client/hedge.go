@@ -0,0 +1,295 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// HedgeConfig enables hedged requests: a unary read that hasn't answered
+// after a delay is sent again on another pooled conn, the first answer is
+// used and the other call is cancelled.
+type HedgeConfig struct {
+	// wait before the second request (0 = only hedge by Percentile)
+	Delay time.Duration
+	// hedge once a call takes longer than this quantile of recent latencies
+	// of its RPC, e.g. 0.95; Delay applies until enough calls were seen
+	// (0 = off)
+	Percentile float64
+	// RPCs to hedge, by Method.Name; only idempotent unary RPCs are
+	// (default = Query, GetTable, ListTables and GetStats)
+	Methods []string
+}
+
+var defaultHedgeMethods = []string{
+	methodQuery.Name(),
+	methodGetTable.Name(),
+	methodListTables.Name(),
+	methodGetStats.Name(),
+}
+
+const (
+	// latencies kept per RPC for Percentile
+	hedgeWindow = 256
+	// latencies seen before Percentile replaces Delay
+	hedgeMinSamples = 20
+)
+
+// HedgeStats counts the hedged calls of one RPC.
+type HedgeStats struct {
+	// calls that may have been hedged
+	Calls uint64
+	// calls that sent a second request, and those it answered first
+	Hedged uint64
+	Won    uint64
+	// current delay before hedging (0 = not hedging yet)
+	Delay time.Duration
+}
+
+type hedger struct {
+	cfg     HedgeConfig
+	methods map[string]*hedgeMethod
+}
+
+type hedgeMethod struct {
+	cfg *HedgeConfig
+
+	mu     sync.Mutex
+	lat    [hedgeWindow]time.Duration
+	n      int // latencies recorded, up to hedgeWindow
+	next   int
+	calls  uint64
+	hedged uint64
+	won    uint64
+}
+
+// newHedger returns the hedger for cfg. Its methods are also hedged by
+// WithHedgeDelay when cfg leaves hedging off.
+func newHedger(cfg HedgeConfig) *hedger {
+	h := &hedger{cfg: cfg, methods: make(map[string]*hedgeMethod)}
+	names := cfg.Methods
+	if names == nil {
+		names = defaultHedgeMethods
+	}
+	for _, name := range names {
+		h.methods[name] = &hedgeMethod{cfg: &h.cfg}
+	}
+	return h
+}
+
+// method returns the state of m when calls to it may be hedged, or nil.
+func (h *hedger) method(m Method, o *callOptions) *hedgeMethod {
+	if m.Stream() || !m.Idempotent() {
+		return nil
+	}
+	if h.cfg.Delay <= 0 && h.cfg.Percentile <= 0 && o.hedgeDelay == nil {
+		return nil
+	}
+	return h.methods[m.Name()]
+}
+
+// delay returns how long to wait before hedging, or false to not hedge.
+func (hm *hedgeMethod) delay(o *callOptions) (time.Duration, bool) {
+	if o.hedgeDelay != nil {
+		return *o.hedgeDelay, *o.hedgeDelay > 0
+	}
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	d := hm.current()
+	return d, d > 0
+}
+
+// current returns the delay before hedging; hm.mu must be held.
+func (hm *hedgeMethod) current() time.Duration {
+	if hm.cfg.Percentile <= 0 || hm.n < hedgeMinSamples {
+		return hm.cfg.Delay
+	}
+	lat := slices.Clone(hm.lat[:hm.n])
+	slices.Sort(lat)
+	i := int(hm.cfg.Percentile * float64(len(lat)-1))
+	return lat[min(max(i, 0), len(lat)-1)]
+}
+
+// observe records a first request that answered after d.
+func (hm *hedgeMethod) observe(d time.Duration) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.lat[hm.next] = d
+	hm.next = (hm.next + 1) % hedgeWindow
+	hm.n = min(hm.n+1, hedgeWindow)
+}
+
+// count records a call, whether it sent a second request and whether that
+// one answered first.
+func (hm *hedgeMethod) count(hedged, won bool) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.calls++
+	if hedged {
+		hm.hedged++
+	}
+	if won {
+		hm.won++
+	}
+}
+
+// HedgeStats reports hedging by RPC, keyed by Method.Name.
+func (c *Client) HedgeStats() map[string]HedgeStats {
+	out := make(map[string]HedgeStats, len(c.hedger.methods))
+	for name, hm := range c.hedger.methods {
+		hm.mu.Lock()
+		out[name] = HedgeStats{
+			Calls:  hm.calls,
+			Hedged: hm.hedged,
+			Won:    hm.won,
+			Delay:  hm.current(),
+		}
+		hm.mu.Unlock()
+	}
+	return out
+}
+
+// hedgeConn runs callConn, and sends the request once more on another
+// conn when the first hasn't answered after the hedge delay of m. Both run
+// as one call under one in-flight slot, which is held until the winner's
+// lease is released and the other request has given its conn back. The
+// first success wins. A losing hedge is cancelled, while a losing first
+// request runs on until it answers, since Percentile is taken from the
+// latencies of first requests. An error the server answered with wins the
+// same way; a request that failed short of an answer, e.g. on a connection
+// error, leaves it to the other, and the first such error is returned once
+// both failed.
+func hedgeConn[T any](c *Client, ctx context.Context, m Method, o *callOptions, req any, fn func(context.Context, proto.DRPCFlowDBClient) (T, error)) (T, *lease, error) {
+	hm := c.hedger.method(m, o)
+	if hm == nil {
+		return callConn(c, ctx, m, o, req, fn)
+	}
+	d, ok := hm.delay(o)
+	if !ok {
+		start := time.Now()
+		res, l, err := callConn(c, ctx, m, o, req, fn)
+		if err == nil || answered(err) {
+			hm.observe(time.Since(start))
+		}
+		hm.count(false, false)
+		return res, l, err
+	}
+
+	var zero T
+	done, err := c.beginCall(ctx, m, o)
+	if err != nil {
+		return zero, nil, err
+	}
+	// the winner's lease and every request still running
+	var refs atomic.Int32
+	refs.Store(1)
+	unref := func() {
+		if refs.Add(-1) == 0 {
+			done()
+		}
+	}
+
+	type result struct {
+		res T
+		l   *lease
+		err error
+		// 0 for the first request, 1 for the hedge
+		i    int
+		took time.Duration
+	}
+	results := make(chan result, 2)
+	var cancels []context.CancelFunc
+	start := time.Now()
+	run := func(o *callOptions) {
+		i := len(cancels)
+		ctx, cancel := context.WithCancel(ctx)
+		cancels = append(cancels, cancel)
+		go func() {
+			res, l, err := attemptConns(c, ctx, m, o, req, fn)
+			results <- result{res: res, l: l, err: err, i: i, took: time.Since(start)}
+		}()
+	}
+	// drain releases the requests still running once they end, recording
+	// the latency of the first one when it wasn't cancelled
+	drain := func(pending int, observe bool) {
+		refs.Add(int32(pending))
+		go func() {
+			for range pending {
+				r := <-results
+				if observe && r.i == 0 && (r.err == nil || answered(r.err)) {
+					hm.observe(r.took)
+				}
+				if r.l != nil {
+					r.l.release()
+				}
+				cancels[r.i]()
+				unref()
+			}
+		}()
+	}
+
+	run(o)
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	pending, hedged := 1, false
+	var firstErr error
+	for {
+		select {
+		case <-timer.C:
+			hedged = true
+			pending++
+			run(o.hedging())
+		case r := <-results:
+			pending--
+			if r.i == 0 && (r.err == nil || answered(r.err)) {
+				hm.observe(r.took)
+			}
+			if r.err != nil {
+				if firstErr == nil || answered(r.err) {
+					firstErr = r.err
+				}
+				if hedged && pending > 0 && !answered(r.err) {
+					cancels[r.i]()
+					continue
+				}
+				for _, cancel := range cancels {
+					cancel()
+				}
+				hm.count(hedged, false)
+				drain(pending, false)
+				unref()
+				return zero, nil, firstErr
+			}
+
+			hm.count(hedged, r.i == 1)
+			if r.i == 0 && pending > 0 {
+				cancels[1]()
+			}
+			// the winner's context lives as long as its lease
+			release, cancel := r.l.cancel, cancels[r.i]
+			r.l.cancel = func() {
+				release()
+				cancel()
+			}
+			r.l.done = unref
+			drain(pending, r.i == 1)
+			return r.res, r.l, nil
+		}
+	}
+}
+
+// answered reports whether err came from the server rather than from
+// failing to reach it.
+func answered(err error) bool {
+	var le *LimitError
+	return !isConnectionError(err) &&
+		!errors.As(err, &le) &&
+		!errors.Is(err, ErrCircuitOpen) &&
+		!errors.Is(err, ErrShutdown)
+}
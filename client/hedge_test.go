@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// slowGetTable delays GetTable by first, or by hedge for the second
+// request of a hedged call.
+func slowGetTable(first, hedge time.Duration) UnaryInterceptor {
+	return func(ctx context.Context, info *CallInfo, req any, invoke UnaryInvoker) (any, error) {
+		if info.Method.Name() != "GetTable" {
+			return invoke(ctx)
+		}
+		d := first
+		if info.Hedge {
+			d = hedge
+		}
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return invoke(ctx)
+	}
+}
+
+// dialHedged dials a client hedging after 10ms whose GetTable is slowed by
+// slowGetTable, with table "t" seeded.
+func dialHedged(t *testing.T, cfg Config, first, hedge time.Duration) *Client {
+	t.Helper()
+	cfg.PoolSize = 2
+	cfg.Hedge = HedgeConfig{Delay: 10 * time.Millisecond}
+	cfg.UnaryInterceptors = []UnaryInterceptor{slowGetTable(first, hedge)}
+	c := dialTest(t, newTestServer(t), cfg)
+	seed(t, c, "t", "", 1)
+	return c
+}
+
+func TestHedgeWins(t *testing.T) {
+	c := dialHedged(t, Config{}, 200*time.Millisecond, 0)
+
+	start := time.Now()
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d >= 200*time.Millisecond {
+		t.Fatalf("hedged call took %v", d)
+	}
+	if st := c.HedgeStats()["GetTable"]; st.Calls != 1 || st.Hedged != 1 || st.Won != 1 {
+		t.Fatalf("hedge stats %+v", st)
+	}
+
+	// the first request runs on, and its latency is the one recorded
+	hm := c.hedger.methods["GetTable"]
+	eventually(t, func() bool {
+		hm.mu.Lock()
+		defer hm.mu.Unlock()
+		return hm.n == 1
+	}, "first request's latency not recorded")
+	if hm.lat[0] < 200*time.Millisecond {
+		t.Fatalf("recorded latency %v, want the first request's", hm.lat[0])
+	}
+	eventually(t, func() bool { return c.PoolStats().InUse == 0 }, "conns still checked out")
+}
+
+func TestHedgeSharesSlot(t *testing.T) {
+	c := dialHedged(t, Config{MaxInFlight: 1, FailFastOnLimit: true}, 200*time.Millisecond, 0)
+
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	if st := c.HedgeStats()["GetTable"]; st.Won != 1 {
+		t.Fatalf("hedge stats %+v", st)
+	}
+	// held until the first request gave its conn back
+	if st := c.LimiterStats(); st.InFlight != 1 || st.Rejected != 0 {
+		t.Fatalf("limiter stats %+v", st)
+	}
+	eventually(t, func() bool { return c.LimiterStats().InFlight == 0 }, "in-flight slot not freed")
+}
+
+func TestHedgeErrorReleases(t *testing.T) {
+	c := dialHedged(t, Config{}, 50*time.Millisecond, 200*time.Millisecond)
+
+	// the first request's answer wins while the hedge is still waiting
+	if _, err := c.GetTable(context.Background(), "missing"); err == nil || !answered(err) {
+		t.Fatalf("got %v, want the server's error", err)
+	}
+	if st := c.HedgeStats()["GetTable"]; st.Hedged != 1 || st.Won != 0 {
+		t.Fatalf("hedge stats %+v", st)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if st := c.PoolStats(); st.InUse != 0 {
+		t.Fatalf("pool stats %+v", st)
+	}
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/memserver"
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestServer starts a memserver closed at the end of the test.
+func newTestServer(t *testing.T) *memserver.Server {
+	t.Helper()
+	s := memserver.New()
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+// dialTest dials cfg, over an in-process pipe to s unless cfg has an
+// address, and closes the client at the end of the test.
+func dialTest(t *testing.T, s *memserver.Server, cfg Config) *Client {
+	t.Helper()
+	if cfg.Address == "" {
+		cfg.Address = "memserver"
+		cfg.Dialer = s.Dial
+	}
+	if cfg.KeepaliveInterval == 0 {
+		cfg.KeepaliveInterval = -1
+	}
+	c, err := Dial(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// dialServers dials the servers as endpoints named by their keys, the
+// first name being the Address.
+func dialServers(t *testing.T, names []string, servers map[string]*memserver.Server, cfg Config) *Client {
+	t.Helper()
+	cfg.Address, cfg.Endpoints = names[0], names[1:]
+	cfg.Dialer = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return servers[addr].Dial(ctx, network, addr)
+	}
+	return dialTest(t, nil, cfg)
+}
+
+func ts(sec int) *timestamppb.Timestamp {
+	return timestamppb.New(time.Unix(int64(sec), 0))
+}
+
+// seed creates table with n rows under prefix, one per second from 0, each
+// carrying its index.
+func seed(t *testing.T, c *Client, table, prefix string, n int) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := c.CreateTable(ctx, table); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	rows := make([]*proto.Row, n)
+	for i := range rows {
+		rows[i] = &proto.Row{Timestamp: ts(i), Data: rowData(i)}
+	}
+	if _, err := c.Insert(ctx, &proto.InsertRequest{TableName: table, Prefix: prefix, Rows: rows}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+}
+
+// rowData is the payload seed gives row i, padded to 100 bytes.
+func rowData(i int) []byte {
+	return fmt.Appendf(nil, "row-%096d", i)
+}
+
+// rangeQuery asks for the rows of table in [from, to) seconds.
+func rangeQuery(table string, from, to int) *proto.QueryRequest {
+	return &proto.QueryRequest{
+		TableName:     table,
+		FilterOptions: &proto.FilterOptions{From: ts(from), To: ts(to)},
+	}
+}
+
+// streamAll runs req as a StreamQuery and returns the rows delivered.
+func streamAll(t *testing.T, c *Client, p *StreamQueryParams, opts ...CallOption) ([]*proto.Row, *proto.QueryResponse, error) {
+	t.Helper()
+	var rows []*proto.Row
+	p.WithOnRow(func(r *proto.Row) error {
+		rows = append(rows, r)
+		return nil
+	})
+	resp, err := c.StreamQuery(context.Background(), p, opts...)
+	return rows, resp, err
+}
+
+// holdStream starts a StreamQuery over table that stops at its first row,
+// holding its conn and in-flight slot, until the returned func is called;
+// that func then waits for the stream to end and returns its error.
+func holdStream(t *testing.T, c *Client, table string, opts ...CallOption) func() error {
+	t.Helper()
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	var once sync.Once
+	p := NewStreamQueryParams().WithRequest(&proto.QueryRequest{TableName: table}).WithOnRow(func(*proto.Row) error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	})
+	go func() {
+		_, err := c.StreamQuery(context.Background(), p, opts...)
+		done <- err
+	}()
+	select {
+	case <-started:
+	case err := <-done:
+		t.Fatalf("stream ended early: %v", err)
+	}
+	var end sync.Once
+	return func() error {
+		end.Do(func() { close(release) })
+		return <-done
+	}
+}
+
+// checkRows fails unless rows are the seeded rows from, from+1, ... to-1,
+// or in reverse from to-1 down when reverse is set.
+func checkRows(t *testing.T, rows []*proto.Row, from, to int, reverse bool) {
+	t.Helper()
+	if len(rows) != to-from {
+		t.Fatalf("got %d rows, want %d", len(rows), to-from)
+	}
+	for i, r := range rows {
+		want := from + i
+		if reverse {
+			want = to - 1 - i
+		}
+		if string(r.Data) != string(rowData(want)) {
+			t.Fatalf("row %d: got %q, want %q", i, r.Data, rowData(want))
+		}
+	}
+}
+
+// backoffFunc adapts a function to BackoffPolicy.
+type backoffFunc func(attempt int) (time.Duration, bool)
+
+func (f backoffFunc) Next(attempt int, _ time.Duration) (time.Duration, bool) {
+	return f(attempt)
+}
+
+// noWait retries at once.
+var noWait = backoffFunc(func(int) (time.Duration, bool) { return 0, true })
+
+// eventually polls cond until it holds or a second passed.
+func eventually(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// CallInfo describes a call, or one attempt of it, to interceptors.
+type CallInfo struct {
+	Method Method
+	// table the call touches, if any
+	Table string
+	// 1-based attempt number within the call, 0 for the whole call
+	Attempt int
+	// the attempt belongs to the second request of a hedged call
+	Hedge bool
+	// pooled conn running the attempt
+	Lane     Lane
+	Conn     int
+	Endpoint string
+	// set on the whole call once it was answered from Config.Cache
+	CacheHit bool
+}
+
+// CallInvoker runs the whole call.
+type CallInvoker func(ctx context.Context) error
+
+// CallInterceptor wraps a whole call: its attempts, which run on ctx, the
+// reading of its stream, or its answer from the cache. The call's response
+// is seen by the unary and stream interceptors of its attempts.
+type CallInterceptor func(ctx context.Context, info *CallInfo, req any, call CallInvoker) error
+
+// UnaryInvoker sends the request of the attempt and returns the response.
+type UnaryInvoker func(ctx context.Context) (any, error)
+
+// UnaryInterceptor wraps every attempt of a unary call. It sees the request
+// and the response the invoker returns; neither may be modified. Headers go
+// on ctx, e.g. with drpcmetadata.Add.
+type UnaryInterceptor func(ctx context.Context, info *CallInfo, req any, invoke UnaryInvoker) (any, error)
+
+// ClientStream is the receiving side of a server stream.
+type ClientStream interface {
+	// Recv returns the next message, or io.EOF once the server is done.
+	Recv() (any, error)
+}
+
+// Streamer opens the stream of the attempt.
+type Streamer func(ctx context.Context) (ClientStream, error)
+
+// StreamInterceptor wraps the opening of every attempt of a streaming call,
+// and may wrap the returned stream to see each message and the final error.
+type StreamInterceptor func(ctx context.Context, info *CallInfo, req any, open Streamer) (ClientStream, error)
+
+// interceptCall runs fn, the whole call described by info, through the
+// call interceptors, the first configured outermost.
+func (c *Client) interceptCall(ctx context.Context, info *CallInfo, req any, fn CallInvoker) error {
+	ics := c.cfg.CallInterceptors
+	for i := len(ics) - 1; i >= 0; i-- {
+		ic, next := ics[i], fn
+		fn = func(ctx context.Context) error {
+			return ic(ctx, info, req, next)
+		}
+	}
+	return fn(ctx)
+}
+
+// intercept runs fn through the interceptors for info.Method, the first
+// configured interceptor outermost.
+func intercept[T any](c *Client, ctx context.Context, info *CallInfo, req any, fn func(context.Context) (T, error)) (T, error) {
+	if info.Method.Stream() {
+		ics := c.cfg.StreamInterceptors
+		if len(ics) == 0 {
+			return fn(ctx)
+		}
+		open := Streamer(func(ctx context.Context) (ClientStream, error) {
+			res, err := fn(ctx)
+			s, _ := any(res).(ClientStream)
+			return s, err
+		})
+		for i := len(ics) - 1; i >= 0; i-- {
+			ic, next := ics[i], open
+			open = func(ctx context.Context) (ClientStream, error) {
+				return ic(ctx, info, req, next)
+			}
+		}
+		s, err := open(ctx)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		if isNil(s) {
+			var zero T
+			return zero, fmt.Errorf("%s: stream interceptor returned no stream", info.Method.Name())
+		}
+		return any(s).(T), nil
+	}
+
+	ics := c.cfg.UnaryInterceptors
+	if len(ics) == 0 {
+		return fn(ctx)
+	}
+	invoke := UnaryInvoker(func(ctx context.Context) (any, error) {
+		res, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	for i := len(ics) - 1; i >= 0; i-- {
+		ic, next := ics[i], invoke
+		invoke = func(ctx context.Context) (any, error) {
+			return ic(ctx, info, req, next)
+		}
+	}
+	out, err := invoke(ctx)
+	res, ok := out.(T)
+	if err != nil {
+		return res, err
+	}
+	if !ok || isNil(out) {
+		return res, fmt.Errorf("%s: unary interceptor returned %T, want %T", info.Method.Name(), out, res)
+	}
+	return res, nil
+}
+
+// isNil reports whether v is nil or a nil pointer.
+func isNil(v any) bool {
+	rv := reflect.ValueOf(v)
+	return !rv.IsValid() || rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+type recvStream[T any] struct {
+	s interface{ Recv() (T, error) }
+}
+
+func (s recvStream[T]) Recv() (any, error) {
+	return s.s.Recv()
+}
+
+// streamOf adapts a generated stream client to ClientStream.
+func streamOf[T any](s interface{ Recv() (T, error) }, err error) (ClientStream, error) {
+	if err != nil {
+		return nil, err
+	}
+	return recvStream[T]{s}, nil
+}
+
+// recv returns the next message of s as a T.
+func recv[T any](s ClientStream) (T, error) {
+	var zero T
+	msg, err := s.Recv()
+	if err != nil {
+		return zero, authError(err)
+	}
+	m, ok := msg.(T)
+	if !ok || isNil(msg) {
+		return zero, fmt.Errorf("unexpected stream message %T", msg)
+	}
+	return m, nil
+}
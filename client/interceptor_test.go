@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+func TestUnaryInterceptorOrder(t *testing.T) {
+	var calls []string
+	ic := func(name string) UnaryInterceptor {
+		return func(ctx context.Context, info *CallInfo, req any, invoke UnaryInvoker) (any, error) {
+			calls = append(calls, name+" "+info.Method.Name())
+			res, err := invoke(ctx)
+			calls = append(calls, name+" done")
+			return res, err
+		}
+	}
+	c := dialTest(t, newTestServer(t), Config{UnaryInterceptors: []UnaryInterceptor{ic("a"), ic("b")}})
+	if _, err := c.ListTables(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := "a ListTables,b ListTables,b done,a done"
+	if got := strings.Join(calls, ","); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestUnaryInterceptorSeesAttempts(t *testing.T) {
+	var infos []CallInfo
+	c := dialTest(t, newTestServer(t), Config{
+		PoolSize: 2,
+		Backoff:  noWait,
+		UnaryInterceptors: []UnaryInterceptor{func(ctx context.Context, info *CallInfo, req any, invoke UnaryInvoker) (any, error) {
+			if info.Method.Name() != "GetTable" {
+				return invoke(ctx)
+			}
+			infos = append(infos, *info)
+			if info.Attempt == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return invoke(ctx)
+		}},
+	})
+	seed(t, c, "t", "", 1)
+
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || infos[0].Attempt != 1 || infos[1].Attempt != 2 {
+		t.Fatalf("attempts %+v", infos)
+	}
+	if infos[1].Table != "t" || infos[1].Endpoint != "memserver" || infos[1].Lane != LaneUnary {
+		t.Fatalf("call info %+v", infos[1])
+	}
+}
+
+func TestUnaryInterceptorBadResult(t *testing.T) {
+	for name, out := range map[string]any{
+		"nil":        nil,
+		"nil ptr":    (*proto.ListTablesResponse)(nil),
+		"wrong type": &proto.Table{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := dialTest(t, newTestServer(t), Config{
+				UnaryInterceptors: []UnaryInterceptor{func(context.Context, *CallInfo, any, UnaryInvoker) (any, error) {
+					return out, nil
+				}},
+			})
+			if _, err := c.ListTables(context.Background()); err == nil || !strings.Contains(err.Error(), "unary interceptor returned") {
+				t.Fatalf("got %v, want an error", err)
+			}
+		})
+	}
+}
+
+// countStream counts the messages received through it.
+type countStream struct {
+	ClientStream
+	n *int
+}
+
+func (s countStream) Recv() (any, error) {
+	msg, err := s.ClientStream.Recv()
+	if err == nil {
+		*s.n++
+	}
+	return msg, err
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	var n int
+	var methods []string
+	c := dialTest(t, newTestServer(t), Config{
+		StreamInterceptors: []StreamInterceptor{func(ctx context.Context, info *CallInfo, req any, open Streamer) (ClientStream, error) {
+			methods = append(methods, info.Method.Name())
+			s, err := open(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return countStream{s, &n}, nil
+		}},
+	})
+	seed(t, c, "t", "", 250)
+	req := &proto.QueryRequest{TableName: "t", StreamOptions: &proto.StreamOptions{RowsPerChunk: Uint32(100)}}
+	rows, _, err := streamAll(t, c, NewStreamQueryParams().WithRequest(req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRows(t, rows, 0, 250, false)
+	// the header, three batches and the footer
+	if n != 5 || len(methods) != 1 || methods[0] != "StreamQuery" {
+		t.Fatalf("%d messages, methods %v", n, methods)
+	}
+}
+
+type nilStream struct{}
+
+func (nilStream) Recv() (any, error) { return (*proto.StreamQueryChunk)(nil), nil }
+
+func TestStreamInterceptorBadStream(t *testing.T) {
+	for name, s := range map[string]ClientStream{
+		"no stream":   nil,
+		"nil message": nilStream{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := dialTest(t, newTestServer(t), Config{
+				StreamInterceptors: []StreamInterceptor{func(context.Context, *CallInfo, any, Streamer) (ClientStream, error) {
+					return s, nil
+				}},
+			})
+			_, _, err := streamAll(t, c, NewStreamQueryParams().WithRequest(&proto.QueryRequest{TableName: "t"}))
+			if err == nil {
+				t.Fatal("no error")
+			}
+		})
+	}
+}
+
+func TestCallInterceptor(t *testing.T) {
+	var calls []CallInfo
+	var attempts int
+	c := dialTest(t, newTestServer(t), Config{
+		PoolSize: 2,
+		Backoff:  noWait,
+		CallInterceptors: []CallInterceptor{func(ctx context.Context, info *CallInfo, req any, call CallInvoker) error {
+			err := call(ctx)
+			calls = append(calls, *info)
+			return err
+		}},
+		UnaryInterceptors: []UnaryInterceptor{func(ctx context.Context, info *CallInfo, req any, invoke UnaryInvoker) (any, error) {
+			attempts++
+			if info.Attempt == 1 && info.Method.Name() == "GetTable" {
+				return nil, errors.New("connection reset by peer")
+			}
+			return invoke(ctx)
+		}},
+	})
+	seed(t, c, "t", "", 1)
+	calls, attempts = nil, 0
+
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 || attempts != 2 {
+		t.Fatalf("%d calls over %d attempts, want 1 over 2", len(calls), attempts)
+	}
+	if info := calls[0]; info.Method.Name() != "GetTable" || info.Table != "t" || info.Attempt != 0 || info.CacheHit {
+		t.Fatalf("call info %+v", info)
+	}
+
+	if _, _, err := streamAll(t, c, NewStreamQueryParams().WithRequest(&proto.QueryRequest{TableName: "t"})); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || calls[1].Method.Name() != "StreamQuery" {
+		t.Fatalf("calls %+v", calls)
+	}
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// errStopped is returned by the callbacks behind an iterator whose loop
+// ended early.
+var errStopped = errors.New("iteration stopped")
+
+// QueryRows iterates the rows of a StreamQuery; see Client.Rows.
+type QueryRows struct {
+	c      *Client
+	ctx    context.Context
+	params *StreamQueryParams
+	opts   []CallOption
+
+	resp *proto.QueryResponse
+}
+
+// Rows returns the rows of req, streamed as by StreamQuery. Each loop over
+// All runs the query again.
+func (c *Client) Rows(ctx context.Context, req *proto.QueryRequest, opts ...CallOption) *QueryRows {
+	return &QueryRows{
+		c:      c,
+		ctx:    ctx,
+		params: NewStreamQueryParams().WithRequest(req),
+		opts:   opts,
+	}
+}
+
+// WithResume resumes the query after a connection loss, as
+// StreamQueryParams.WithResume.
+func (r *QueryRows) WithResume(resume bool) *QueryRows {
+	r.params.WithResume(resume)
+	return r
+}
+
+// All yields the rows in order. A failed query yields its error once and
+// ends the loop. Breaking out of the loop closes the stream and returns its
+// connection to the pool right away.
+func (r *QueryRows) All() iter.Seq2[*proto.Row, error] {
+	return func(yield func(*proto.Row, error) bool) {
+		r.resp = nil
+		params := *r.params
+		params.onRow = func(row *proto.Row) error {
+			if !yield(row, nil) {
+				return errStopped
+			}
+			return nil
+		}
+
+		resp, err := r.c.StreamQuery(r.ctx, &params, r.opts...)
+		if errors.Is(err, errStopped) {
+			return
+		}
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		r.resp = resp
+	}
+}
+
+// Response returns the stats of the last loop over All that read every
+// row, or nil.
+func (r *QueryRows) Response() *proto.QueryResponse {
+	return r.resp
+}
+
+// BackupStats describes a backup read through BackupChunks.
+type BackupStats struct {
+	Chunks int
+	Bytes  uint64
+	// database version the backup was taken at
+	Version uint64
+}
+
+// BackupChunks iterates the chunks of a Backup; see Client.BackupChunks.
+type BackupChunks struct {
+	c       *Client
+	ctx     context.Context
+	version uint64
+	comp    proto.CompressionMethod
+	opts    []CallOption
+
+	stats *BackupStats
+}
+
+// BackupChunks returns the chunks of a backup of the changes since version,
+// streamed as by Backup. Each loop over All takes the backup again.
+func (c *Client) BackupChunks(ctx context.Context, version uint64, comp proto.CompressionMethod, opts ...CallOption) *BackupChunks {
+	return &BackupChunks{c: c, ctx: ctx, version: version, comp: comp, opts: opts}
+}
+
+// All yields the chunks in order. A failed backup yields its error once
+// and ends the loop. Breaking out of the loop closes the stream and returns
+// its connection to the pool right away.
+func (b *BackupChunks) All() iter.Seq2[*proto.BackupChunk, error] {
+	return func(yield func(*proto.BackupChunk, error) bool) {
+		b.stats = nil
+		st := &BackupStats{}
+		err := b.c.Backup(b.ctx, b.version, b.comp, func(chunk *proto.BackupChunk) error {
+			st.Chunks++
+			st.Bytes += uint64(len(chunk.Data))
+			st.Version = chunk.Version
+			if !yield(chunk, nil) {
+				return errStopped
+			}
+			return nil
+		}, b.opts...)
+		if errors.Is(err, errStopped) {
+			return
+		}
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		b.stats = st
+	}
+}
+
+// Stats returns the totals of the last loop over All that read every
+// chunk, or nil.
+func (b *BackupChunks) Stats() *BackupStats {
+	return b.stats
+}
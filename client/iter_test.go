@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// checkReleased fails unless the single pooled conn of c is back in the
+// pool and, when abandoned, was disconnected rather than left mid-stream.
+func checkReleased(t *testing.T, c *Client, abandoned bool) {
+	t.Helper()
+	if st := c.PoolStats(); st.InUse != 0 {
+		t.Fatalf("%d conns still checked out", st.InUse)
+	}
+	if st := c.LimiterStats(); st.InFlight != 0 {
+		t.Fatalf("%d in-flight slots held", st.InFlight)
+	}
+	if got := c.pool.all()[0].currentState(); (got == ConnDisconnected) != abandoned {
+		t.Fatalf("conn %v after the call", got)
+	}
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("next call: %v", err)
+	}
+}
+
+func TestRowsAll(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 1, MaxInFlight: 1})
+	seed(t, c, "t", "", 250)
+	req := &proto.QueryRequest{TableName: "t", StreamOptions: &proto.StreamOptions{RowsPerChunk: Uint32(100)}}
+
+	rows := c.Rows(context.Background(), req)
+	for range 2 {
+		var got []*proto.Row
+		for row, err := range rows.All() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, row)
+		}
+		checkRows(t, got, 0, 250, false)
+		if resp := rows.Response(); resp == nil || resp.Count != 250 {
+			t.Fatalf("response %v", resp)
+		}
+	}
+	checkReleased(t, c, false)
+}
+
+func TestRowsBreak(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 1, MaxInFlight: 1})
+	seed(t, c, "t", "", 250)
+	req := &proto.QueryRequest{TableName: "t", StreamOptions: &proto.StreamOptions{RowsPerChunk: Uint32(100)}}
+
+	rows := c.Rows(context.Background(), req)
+	n := 0
+	for _, err := range rows.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 150 {
+			break
+		}
+	}
+	if rows.Response() != nil {
+		t.Fatal("response of a loop that stopped early")
+	}
+	checkReleased(t, c, true)
+}
+
+func TestRowsError(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 1})
+	var errs int
+	for row, err := range c.Rows(context.Background(), &proto.QueryRequest{TableName: "missing"}).All() {
+		if err == nil || row != nil {
+			t.Fatalf("yielded %v, %v", row, err)
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Fatalf("%d errors yielded", errs)
+	}
+}
+
+func TestBackupChunks(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 1, MaxInFlight: 1})
+	seed(t, c, "t", "", 30000)
+	chunks := c.BackupChunks(context.Background(), 0, proto.CompressionMethod_CompressionNone)
+
+	var n int
+	var bytes uint64
+	for chunk, err := range chunks.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+		bytes += uint64(len(chunk.Data))
+	}
+	st := chunks.Stats()
+	if n < 2 || st == nil || st.Chunks != n || st.Bytes != bytes || st.Version == 0 {
+		t.Fatalf("%d chunks, stats %+v", n, st)
+	}
+	checkReleased(t, c, false)
+
+	for _, err := range chunks.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		break
+	}
+	if chunks.Stats() != nil {
+		t.Fatal("stats of a loop that stopped early")
+	}
+	checkReleased(t, c, true)
+}
+
+var errCallback = errors.New("callback failed")
+
+func TestBackupToS3CallbackError(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 1, MaxInFlight: 1})
+	seed(t, c, "t", "", 10)
+	p := NewBackupToS3Params().
+		WithRequest(&proto.S3BackupRequest{S3Config: &proto.S3Config{Bucket: "b"}}).
+		WithOnHeader(func(*proto.S3BackupHeader) error { return errCallback })
+	if _, err := c.BackupToS3(context.Background(), p); !errors.Is(err, errCallback) {
+		t.Fatalf("got %v", err)
+	}
+	checkReleased(t, c, true)
+}
+
+func TestRestoreFromS3CallbackError(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 1, MaxInFlight: 1})
+	seed(t, c, "t", "", 10)
+	cfg := &proto.S3Config{Bucket: "b"}
+	if _, err := c.BackupToS3(context.Background(), NewBackupToS3Params().WithRequest(&proto.S3BackupRequest{S3Config: cfg})); err != nil {
+		t.Fatal(err)
+	}
+	p := NewRestoreFromS3Params().
+		WithRequest(&proto.S3RestoreRequest{S3Config: cfg}).
+		WithOnHeader(func(*proto.S3RestoreHeader) error { return errCallback })
+	if _, err := c.RestoreFromS3(context.Background(), p); !errors.Is(err, errCallback) {
+		t.Fatalf("got %v", err)
+	}
+	checkReleased(t, c, true)
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+type ConnState int
+
+const (
+	// never dialed, or closed by Client.Close
+	ConnDisconnected ConnState = iota
+	ConnReady
+	// failed a call or probe, or was closed by the server; redialed on the
+	// next use or keepalive probe
+	ConnBroken
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case ConnDisconnected:
+		return "disconnected"
+	case ConnReady:
+		return "ready"
+	case ConnBroken:
+		return "broken"
+	default:
+		return "unknown"
+	}
+}
+
+type ConnStateChange struct {
+	// index of the conn within its lane
+	Conn     int
+	Lane     Lane
+	Endpoint string
+	From, To ConnState
+	// cause of the change, when known
+	Err error
+}
+
+// transition moves w to state to and appends the change to events. The
+// caller holds w.mu and passes events to notify once it is released.
+func (w *conn) transition(events []ConnStateChange, to ConnState, err error) []ConnStateChange {
+	if w.state == to {
+		return events
+	}
+
+	ev := ConnStateChange{
+		Conn: w.id,
+		Lane: w.lane,
+		From: w.state,
+		To:   to,
+		Err:  err,
+	}
+	if w.ep != nil {
+		ev.Endpoint = w.ep.addr
+	}
+	w.state = to
+	return append(events, ev)
+}
+
+func (c *Client) notify(events []ConnStateChange) {
+	if c.cfg.OnConnStateChange == nil {
+		return
+	}
+	for _, ev := range events {
+		c.cfg.OnConnStateChange(ev)
+	}
+}
+
+func (w *conn) currentState() ConnState {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.state
+}
+
+func (c *Client) keepaliveLoop() {
+	defer c.wg.Done()
+
+	t := time.NewTicker(c.cfg.KeepaliveInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		for _, p := range []*connPool{c.pool, c.streamPool} {
+			if p == nil {
+				continue
+			}
+			for _, w := range p.all() {
+				if c.ctx.Err() != nil {
+					return
+				}
+				// never-dialed conns stay lazy; busy ones prove themselves
+				if w.currentState() == ConnDisconnected || !p.take(w) {
+					continue
+				}
+				c.probe(w)
+				p.put(w)
+			}
+		}
+	}
+}
+
+// probe pings an idle conn, redialing it first when it is broken, and
+// replaces it right away when the ping fails.
+func (c *Client) probe(w *conn) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.cfg.Timeout)
+	defer cancel()
+
+	cli, err := w.ensureClient(ctx)
+	if err != nil {
+		return
+	}
+	ep := w.connectedTo()
+
+	// any answer, even an error from a server without Ping, proves the
+	// conn is alive
+	_, err = cli.Ping(ctx, &proto.Empty{})
+	if !isConnectionError(err) {
+		if ep != nil {
+			ep.success()
+		}
+		return
+	}
+	if c.ctx.Err() != nil {
+		return
+	}
+
+	c.reportFailure(ep, err)
+	w.markBroken()
+	_, _ = w.ensureClient(ctx)
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/faultproxy"
+)
+
+func TestKeepaliveDetectsDeadPeer(t *testing.T) {
+	addr, err := newTestServer(t).Listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := faultproxy.New(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = proxy.Close() })
+
+	var mu sync.Mutex
+	var events []ConnStateChange
+	// seen returns the first state change to state
+	seen := func(state ConnState) (ConnStateChange, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, ev := range events {
+			if ev.To == state {
+				return ev, true
+			}
+		}
+		return ConnStateChange{}, false
+	}
+	c := dialTest(t, nil, Config{
+		Address:           proxy.Addr(),
+		PoolSize:          1,
+		Timeout:           100 * time.Millisecond,
+		KeepaliveInterval: 20 * time.Millisecond,
+		OnConnStateChange: func(ev ConnStateChange) {
+			mu.Lock()
+			events = append(events, ev)
+			mu.Unlock()
+		},
+	})
+
+	// the server stops answering in time without closing the conn
+	_ = proxy.SetFaults(faultproxy.Faults{Latency: 300 * time.Millisecond})
+	eventually(t, func() bool {
+		_, ok := seen(ConnBroken)
+		return ok
+	}, "unresponsive conn not marked broken")
+	if ev, _ := seen(ConnBroken); ev.From != ConnReady || ev.Lane != LaneUnary || ev.Endpoint != proxy.Addr() {
+		t.Fatalf("state change %+v", ev)
+	}
+
+	// once the server answers again the probes find the conn alive
+	_ = proxy.Heal()
+	eventually(t, func() bool {
+		return c.conns()[0].currentState() == ConnReady && c.PoolStats().InUse == 0
+	}, "conn not redialed")
+	if _, err := c.ListTables(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,315 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrLimited = errors.New("client limit reached")
+
+// LimitError is returned by calls rejected by a client-side limit. It
+// matches ErrLimited with errors.Is.
+type LimitError struct {
+	// "in-flight", "method" or "table"
+	Limit  string
+	Method string
+	Table  string
+}
+
+func (e *LimitError) Error() string {
+	switch e.Limit {
+	case "in-flight":
+		return fmt.Sprintf("%s: in-flight limit for %s", ErrLimited, e.Method)
+	case "table":
+		return fmt.Sprintf("%s: rate limit for table %q", ErrLimited, e.Table)
+	default:
+		return fmt.Sprintf("%s: rate limit for %s", ErrLimited, e.Method)
+	}
+}
+
+func (e *LimitError) Unwrap() error { return ErrLimited }
+
+// RateLimit is a token bucket refilled at Rate calls per second.
+type RateLimit struct {
+	Rate float64
+	// calls allowed at once after idling (default = Rate, at least 1)
+	Burst int
+}
+
+type bucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(l RateLimit) *bucket {
+	burst := float64(l.Burst)
+	if burst <= 0 {
+		burst = max(1, math.Ceil(l.Rate))
+	}
+	return &bucket{rate: l.Rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// refill adds the tokens earned since the last call. The caller holds b.mu.
+func (b *bucket) refill() {
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// reserve takes a token and returns how long to wait until it is due. With
+// failFast it fails instead of reserving a token that isn't there yet.
+func (b *bucket) reserve(failFast bool) (time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, nil
+	}
+	if failFast {
+		return 0, ErrLimited
+	}
+	// reserving ahead of time serves waiters in order
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	b.tokens--
+	return wait, nil
+}
+
+// cancel gives back a reserved token whose caller stopped waiting.
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+func (b *bucket) available() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	return b.tokens
+}
+
+// buckets kept for tables limited by the "" entry of
+// Config.TableRateLimits; the least recently used is dropped beyond that and
+// its table starts over with a full bucket
+const maxSharedTableBuckets = 4096
+
+// limiter applies Config.MaxInFlight, Config.MethodRateLimits and
+// Config.TableRateLimits to logical calls; retries of a call don't take
+// another slot or token.
+type limiter struct {
+	inflight chan struct{} // nil = unlimited
+	methods  map[string]*bucket
+	// tables with a limit of their own
+	tables map[string]*bucket
+	// limit of every other table (Rate 0 = none)
+	shared RateLimit
+
+	mu        sync.Mutex
+	sharedIdx map[string]*list.Element
+	sharedLRU *list.List // of *sharedBucket, most recently used first
+
+	waiting  atomic.Int64
+	waited   atomic.Uint64
+	rejected atomic.Uint64
+	waitTime atomic.Int64
+}
+
+func newLimiter(cfg *Config) *limiter {
+	l := &limiter{
+		methods:   make(map[string]*bucket, len(cfg.MethodRateLimits)),
+		tables:    make(map[string]*bucket, len(cfg.TableRateLimits)),
+		shared:    cfg.TableRateLimits[""],
+		sharedIdx: make(map[string]*list.Element),
+		sharedLRU: list.New(),
+	}
+	if cfg.MaxInFlight > 0 {
+		l.inflight = make(chan struct{}, cfg.MaxInFlight)
+	}
+	for name, rl := range cfg.MethodRateLimits {
+		if rl.Rate > 0 {
+			l.methods[name] = newBucket(rl)
+		}
+	}
+	for name, rl := range cfg.TableRateLimits {
+		// nil marks a table exempt from the "" limit
+		if name != "" {
+			l.tables[name] = nil
+			if rl.Rate > 0 {
+				l.tables[name] = newBucket(rl)
+			}
+		}
+	}
+	return l
+}
+
+type sharedBucket struct {
+	table string
+	b     *bucket
+}
+
+// tableBucket returns the bucket of table: its own, or one made from the
+// "" limit on first use.
+func (l *limiter) tableBucket(table string) *bucket {
+	if table == "" {
+		return nil
+	}
+	if b, ok := l.tables[table]; ok || l.shared.Rate <= 0 {
+		return b
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.sharedIdx[table]; ok {
+		l.sharedLRU.MoveToFront(el)
+		return el.Value.(*sharedBucket).b
+	}
+	if l.sharedLRU.Len() >= maxSharedTableBuckets {
+		old := l.sharedLRU.Remove(l.sharedLRU.Back()).(*sharedBucket)
+		delete(l.sharedIdx, old.table)
+	}
+	tb := &sharedBucket{table: table, b: newBucket(l.shared)}
+	l.sharedIdx[table] = l.sharedLRU.PushFront(tb)
+	return tb.b
+}
+
+// acquire waits for every limit that applies to the call, or fails with a
+// *LimitError when failFast is set. The returned func frees the in-flight
+// slot.
+func (l *limiter) acquire(ctx context.Context, m Method, table string, failFast bool) (func(), error) {
+	if l.inflight == nil && len(l.methods) == 0 && len(l.tables) == 0 && l.shared.Rate <= 0 {
+		return func() {}, nil
+	}
+
+	var reserved []*bucket
+	fail := func(err error) (func(), error) {
+		for _, b := range reserved {
+			b.cancel()
+		}
+		return nil, err
+	}
+
+	limits := []struct {
+		b     *bucket
+		limit string
+	}{
+		{l.methods[m.Name()], "method"},
+		{l.tableBucket(table), "table"},
+	}
+	for _, lim := range limits {
+		if lim.b == nil {
+			continue
+		}
+		wait, err := lim.b.reserve(failFast)
+		if err != nil {
+			l.rejected.Add(1)
+			return fail(&LimitError{Limit: lim.limit, Method: m.Name(), Table: table})
+		}
+		reserved = append(reserved, lim.b)
+		if wait > 0 {
+			if err := l.wait(ctx, func(ctx context.Context) error { return sleepCtx(ctx, wait) }); err != nil {
+				return fail(err)
+			}
+		}
+	}
+
+	if l.inflight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inflight <- struct{}{}:
+	default:
+		if failFast {
+			l.rejected.Add(1)
+			return fail(&LimitError{Limit: "in-flight", Method: m.Name(), Table: table})
+		}
+		err := l.wait(ctx, func(ctx context.Context) error {
+			select {
+			case l.inflight <- struct{}{}:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-l.inflight }) }, nil
+}
+
+// wait runs block, counting it in the waiting metrics.
+func (l *limiter) wait(ctx context.Context, block func(context.Context) error) error {
+	start := time.Now()
+	l.waiting.Add(1)
+	defer func() {
+		l.waiting.Add(-1)
+		l.waited.Add(1)
+		l.waitTime.Add(int64(time.Since(start)))
+	}()
+	return block(ctx)
+}
+
+type LimiterStats struct {
+	// calls holding an in-flight slot, and the limit (0 = unlimited)
+	InFlight    int
+	MaxInFlight int
+	// calls currently blocked on a limit
+	Waiting int64
+	// times calls had to wait, and calls rejected with a *LimitError
+	Waited   uint64
+	Rejected uint64
+	// total time calls spent waiting on limits
+	WaitTime time.Duration
+	// tokens left in each rate limit bucket, keyed by "method:<name>" or
+	// "table:<name>"; negative while waiting calls hold future tokens
+	Tokens map[string]float64
+}
+
+// LimiterStats reports the state of the client-side limits.
+func (c *Client) LimiterStats() LimiterStats {
+	l := c.limiter
+	s := LimiterStats{
+		InFlight:    len(l.inflight),
+		MaxInFlight: cap(l.inflight),
+		Waiting:     l.waiting.Load(),
+		Waited:      l.waited.Load(),
+		Rejected:    l.rejected.Load(),
+		WaitTime:    time.Duration(l.waitTime.Load()),
+		Tokens:      make(map[string]float64),
+	}
+	for name, b := range l.methods {
+		s.Tokens["method:"+name] = b.available()
+	}
+
+	tables := make(map[string]*bucket, len(l.tables))
+	for name, b := range l.tables {
+		if b != nil {
+			tables[name] = b
+		}
+	}
+	l.mu.Lock()
+	for el := l.sharedLRU.Front(); el != nil; el = el.Next() {
+		tb := el.Value.(*sharedBucket)
+		tables[tb.table] = tb.b
+	}
+	l.mu.Unlock()
+	for name, b := range tables {
+		s.Tokens["table:"+name] = b.available()
+	}
+	return s
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestMethodRateLimit(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{
+		MethodRateLimits: map[string]RateLimit{"ListTables": {Rate: 0.001, Burst: 2}},
+		FailFastOnLimit:  true,
+	})
+	ctx := context.Background()
+	for range 2 {
+		if _, err := c.ListTables(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := c.ListTables(ctx)
+	var le *LimitError
+	if !errors.As(err, &le) || le.Limit != "method" || !errors.Is(err, ErrLimited) {
+		t.Fatalf("got %v, want a method LimitError", err)
+	}
+	if _, err := c.GetStats(ctx); err != nil {
+		t.Fatalf("other method limited: %v", err)
+	}
+	if st := c.LimiterStats(); st.Rejected != 1 {
+		t.Fatalf("limiter stats %+v", st)
+	}
+}
+
+func TestMethodRateLimitWaits(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{
+		MethodRateLimits: map[string]RateLimit{"ListTables": {Rate: 50, Burst: 1}},
+	})
+	start := time.Now()
+	for range 3 {
+		if _, err := c.ListTables(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// two tokens at 50/s
+	if d := time.Since(start); d < 35*time.Millisecond {
+		t.Fatalf("3 calls took %v", d)
+	}
+	if st := c.LimiterStats(); st.Waited != 2 {
+		t.Fatalf("limiter stats %+v", st)
+	}
+}
+
+func TestTableRateLimit(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{
+		TableRateLimits: map[string]RateLimit{
+			"a":    {Rate: 0.001, Burst: 1},
+			"free": {},
+			"":     {Rate: 0.001, Burst: 2},
+		},
+		FailFastOnLimit: true,
+	})
+	limited := func(table string) bool {
+		_, err := c.GetTable(context.Background(), table)
+		var le *LimitError
+		return errors.As(err, &le) && le.Limit == "table" && le.Table == table
+	}
+	for _, tc := range []struct {
+		table string
+		calls int
+	}{{"a", 1}, {"b", 2}, {"c", 2}} {
+		for i := range tc.calls {
+			if limited(tc.table) {
+				t.Fatalf("table %s limited at call %d", tc.table, i+1)
+			}
+		}
+		if !limited(tc.table) {
+			t.Fatalf("table %s not limited after %d calls", tc.table, tc.calls)
+		}
+	}
+	for range 5 {
+		if limited("free") {
+			t.Fatal("table without a limit limited")
+		}
+	}
+}
+
+func TestSharedTableBucketsBounded(t *testing.T) {
+	l := newLimiter(&Config{TableRateLimits: map[string]RateLimit{"a": {Rate: 1}, "": {Rate: 1}}})
+	first := l.tableBucket("t0")
+	for i := range maxSharedTableBuckets + 10 {
+		l.tableBucket(fmt.Sprintf("t%d", i))
+	}
+	if n := l.sharedLRU.Len(); n != maxSharedTableBuckets || len(l.sharedIdx) != n {
+		t.Fatalf("%d shared buckets kept", n)
+	}
+	if l.tableBucket("t0") == first {
+		t.Fatal("least recently used bucket kept")
+	}
+	if l.tableBucket("a") != l.tables["a"] {
+		t.Fatal("table with its own limit got a shared bucket")
+	}
+}
+
+func TestMaxInFlight(t *testing.T) {
+	s := newTestServer(t)
+	c := dialTest(t, s, Config{MaxInFlight: 1})
+	seed(t, c, "t", "", 10)
+
+	release := holdStream(t, c, "t")
+	if st := c.LimiterStats(); st.InFlight != 1 || st.MaxInFlight != 1 {
+		t.Fatalf("limiter stats %+v", st)
+	}
+	_, err := c.ListTables(context.Background(), WithFailFast(true))
+	var le *LimitError
+	if !errors.As(err, &le) || le.Limit != "in-flight" {
+		t.Fatalf("got %v, want an in-flight LimitError", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.ListTables(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want to wait until the deadline", err)
+	}
+
+	if err := release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListTables(context.Background(), WithFailFast(true)); err != nil {
+		t.Fatal(err)
+	}
+	if st := c.LimiterStats(); st.InFlight != 0 || st.Waited != 1 || st.Rejected != 1 {
+		t.Fatalf("limiter stats %+v", st)
+	}
+}
+
+func TestRetryTakesOneToken(t *testing.T) {
+	dials := 0
+	s := newTestServer(t)
+	c := dialTest(t, s, Config{
+		Lazy:             true,
+		PoolSize:         1,
+		Backoff:          noWait,
+		MethodRateLimits: map[string]RateLimit{"ListTables": {Rate: 0.001, Burst: 1}},
+		FailFastOnLimit:  true,
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials++
+			if dials < 3 {
+				return nil, errors.New("refused")
+			}
+			return s.Dial(ctx, network, addr)
+		},
+		Address: "memserver",
+	})
+	if _, err := c.ListTables(context.Background(), WithMaxRetries(3)); err != nil {
+		t.Fatalf("call not retried within its token: %v", err)
+	}
+	if dials != 3 {
+		t.Fatalf("%d dials", dials)
+	}
+}
+
+func TestFailedCallFreesSlot(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{MaxInFlight: 1, FailFastOnLimit: true})
+
+	for range 2 {
+		if _, err := c.GetTable(context.Background(), "missing"); !answered(err) {
+			t.Fatalf("got %v, want the server's error", err)
+		}
+	}
+	if st := c.LimiterStats(); st.InFlight != 0 || st.Rejected != 0 {
+		t.Fatalf("limiter stats %+v", st)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"maps"
+	"reflect"
+
+	"storj.io/drpc/drpcmetadata"
+)
+
+// WithMetadata returns ctx carrying the drpc metadata already on ctx plus
+// pairs, sent to the server with every call made with it. Unlike
+// drpcmetadata.Add it never modifies the map held by ctx, so a ctx shared by
+// concurrent calls stays safe to use.
+func WithMetadata(ctx context.Context, pairs map[string]string) context.Context {
+	md, ok := drpcmetadata.Get(ctx)
+	if !ok {
+		return drpcmetadata.AddPairs(ctx, pairs)
+	}
+
+	merged := maps.Clone(md)
+	maps.Copy(merged, pairs)
+	return drpcmetadata.AddPairs(hideMetadata{Context: ctx, md: md}, merged)
+}
+
+// hideMetadata hides md from drpcmetadata.Get, so drpcmetadata.Add attaches
+// a new map instead of writing to md.
+type hideMetadata struct {
+	context.Context
+	md map[string]string
+}
+
+func (c hideMetadata) Value(key any) any {
+	v := c.Context.Value(key)
+	if m, ok := v.(map[string]string); ok && reflect.ValueOf(m).UnsafePointer() == reflect.ValueOf(c.md).UnsafePointer() {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Method describes a FlowDB RPC as seen by the client.
+type Method struct {
+	name       string
+	idempotent bool
+	stream     bool
+}
+
+// Name is the RPC's name in the FlowDB service, e.g. "Query".
+func (m Method) Name() string { return m.name }
+
+// Idempotent reports whether the call is safe to resend after a connection
+// error that may have happened after the server applied it.
+func (m Method) Idempotent() bool { return m.idempotent }
+
+// Stream reports whether the RPC is server streaming.
+func (m Method) Stream() bool { return m.stream }
+
+var (
+	methodCreateTable = Method{name: "CreateTable"}
+	methodDropTable   = Method{name: "DropTable"}
+	// retried only with Config.IdempotentInserts
+	methodInsert        = Method{name: "Insert"}
+	methodDelete        = Method{name: "Delete"}
+	methodQuery         = Method{name: "Query", idempotent: true}
+	methodStreamQuery   = Method{name: "StreamQuery", idempotent: true, stream: true}
+	methodGetTable      = Method{name: "GetTable", idempotent: true}
+	methodListTables    = Method{name: "ListTables", idempotent: true}
+	methodBackup        = Method{name: "Backup", idempotent: true, stream: true}
+	methodBackupToS3    = Method{name: "BackupToS3", stream: true}
+	methodRestoreFromS3 = Method{name: "RestoreFromS3", stream: true}
+	methodGetStats      = Method{name: "GetStats", idempotent: true}
+	methodPing          = Method{name: "Ping", idempotent: true}
+)
+
+// newIdempotencyKey returns a random key identifying one logical call.
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
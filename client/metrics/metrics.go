@@ -0,0 +1,313 @@
+// Package metrics exports client.Client metrics to Prometheus.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/client"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "flowdb_client"
+
+type config struct {
+	buckets     []float64
+	constLabels prometheus.Labels
+}
+
+type Option func(*config)
+
+// WithBuckets sets the request duration histogram buckets in seconds
+// (default = prometheus.DefBuckets).
+func WithBuckets(b []float64) Option {
+	return func(c *config) { c.buckets = b }
+}
+
+// WithConstLabels adds labels to every metric, e.g. to tell clients apart.
+func WithConstLabels(l prometheus.Labels) Option {
+	return func(c *config) { c.constLabels = l }
+}
+
+// Collector is a prometheus.Collector for one client.Client. Instrument
+// must be called on the Config before Dial, and Watch with the Client
+// afterwards for the pool gauges and hedge counters.
+type Collector struct {
+	requests   *prometheus.CounterVec
+	retries    *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	sent       *prometheus.CounterVec
+	received   *prometheus.CounterVec
+	reconnects *prometheus.CounterVec
+	broken     *prometheus.CounterVec
+	cacheHits  *prometheus.CounterVec
+
+	poolConns   *prometheus.Desc
+	poolBusy    *prometheus.Desc
+	poolWaiting *prometheus.Desc
+	hedged      *prometheus.Desc
+	hedgeWins   *prometheus.Desc
+
+	mu     sync.Mutex
+	client *client.Client
+}
+
+func New(opts ...Option) *Collector {
+	cfg := &config{buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	counter := func(name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: cfg.constLabels,
+		}, labels)
+	}
+	desc := func(name, help string, label string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, []string{label}, cfg.constLabels)
+	}
+
+	return &Collector{
+		requests: counter("requests_total", "Call attempts by RPC and result (ok, error, connection_error, canceled).", "method", "result"),
+		retries:  counter("retries_total", "Call attempts after the first, by RPC.", "method"),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Duration of call attempts by RPC; streams until their last message.",
+			Buckets:     cfg.buckets,
+			ConstLabels: cfg.constLabels,
+		}, []string{"method"}),
+		sent:       counter("sent_bytes_total", "Request bytes sent by RPC.", "method"),
+		received:   counter("received_bytes_total", "Response and stream message bytes received by RPC.", "method"),
+		reconnects: counter("reconnects_total", "Broken pooled connections dialed again, by lane.", "lane"),
+		broken:     counter("broken_conns_total", "Pooled connections marked broken, by lane.", "lane"),
+		cacheHits:  counter("cache_hits_total", "Calls answered from the query cache, by RPC.", "method"),
+
+		poolConns:   desc("pool_conns", "Connections in the pool.", "lane"),
+		poolBusy:    desc("pool_busy_conns", "Pooled connections checked out by a call.", "lane"),
+		poolWaiting: desc("pool_waiting_calls", "Calls waiting for a pooled connection.", "lane"),
+		hedged:      desc("hedged_calls_total", "Calls sent again on another connection after the hedge delay, by RPC.", "method"),
+		hedgeWins:   desc("hedge_wins_total", "Hedged calls answered first by the second request, by RPC.", "method"),
+	}
+}
+
+// Instrument adds the collector's interceptors and state hook to cfg,
+// keeping an OnConnStateChange already set.
+func (m *Collector) Instrument(cfg *client.Config) {
+	cfg.CallInterceptors = append(cfg.CallInterceptors, m.call)
+	cfg.UnaryInterceptors = append(cfg.UnaryInterceptors, m.unary)
+	cfg.StreamInterceptors = append(cfg.StreamInterceptors, m.stream)
+
+	prev := cfg.OnConnStateChange
+	cfg.OnConnStateChange = func(ev client.ConnStateChange) {
+		m.connStateChanged(ev)
+		if prev != nil {
+			prev(ev)
+		}
+	}
+}
+
+// Watch sets the client whose pools are reported.
+func (m *Collector) Watch(c *client.Client) {
+	m.mu.Lock()
+	m.client = c
+	m.mu.Unlock()
+}
+
+func (m *Collector) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.retries.Describe(ch)
+	m.duration.Describe(ch)
+	m.sent.Describe(ch)
+	m.received.Describe(ch)
+	m.reconnects.Describe(ch)
+	m.broken.Describe(ch)
+	m.cacheHits.Describe(ch)
+	ch <- m.poolConns
+	ch <- m.poolBusy
+	ch <- m.poolWaiting
+	ch <- m.hedged
+	ch <- m.hedgeWins
+}
+
+func (m *Collector) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.retries.Collect(ch)
+	m.duration.Collect(ch)
+	m.sent.Collect(ch)
+	m.received.Collect(ch)
+	m.reconnects.Collect(ch)
+	m.broken.Collect(ch)
+	m.cacheHits.Collect(ch)
+
+	m.mu.Lock()
+	c := m.client
+	m.mu.Unlock()
+	if c == nil {
+		return
+	}
+
+	lanes := []struct {
+		name  string
+		stats client.PoolStats
+	}{
+		{"unary", c.PoolStats()},
+		{"stream", c.StreamPoolStats()},
+	}
+	for _, l := range lanes {
+		ch <- prometheus.MustNewConstMetric(m.poolConns, prometheus.GaugeValue, float64(l.stats.Size), l.name)
+		ch <- prometheus.MustNewConstMetric(m.poolBusy, prometheus.GaugeValue, float64(l.stats.InUse), l.name)
+		ch <- prometheus.MustNewConstMetric(m.poolWaiting, prometheus.GaugeValue, float64(l.stats.Waiting), l.name)
+	}
+	for method, s := range c.HedgeStats() {
+		ch <- prometheus.MustNewConstMetric(m.hedged, prometheus.CounterValue, float64(s.Hedged), method)
+		ch <- prometheus.MustNewConstMetric(m.hedgeWins, prometheus.CounterValue, float64(s.Won), method)
+	}
+}
+
+func laneName(l client.Lane) string {
+	if l == client.LaneStream {
+		return "stream"
+	}
+	return "unary"
+}
+
+func (m *Collector) connStateChanged(ev client.ConnStateChange) {
+	lane := laneName(ev.Lane)
+	switch {
+	case ev.To == client.ConnBroken:
+		m.broken.WithLabelValues(lane).Inc()
+	case ev.From == client.ConnBroken && ev.To == client.ConnReady:
+		m.reconnects.WithLabelValues(lane).Inc()
+	}
+}
+
+// begin counts the start of an attempt and its request bytes.
+func (m *Collector) begin(info *client.CallInfo, req any) {
+	method := info.Method.Name()
+	if info.Attempt > 1 {
+		m.retries.WithLabelValues(method).Inc()
+	}
+	m.sent.WithLabelValues(method).Add(float64(sizeOf(req)))
+}
+
+// finish counts the end of an attempt that started at start.
+func (m *Collector) finish(method string, start time.Time, err error) {
+	m.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	m.requests.WithLabelValues(method, result(err)).Inc()
+}
+
+// call counts calls answered from the cache, which make no attempts.
+func (m *Collector) call(ctx context.Context, info *client.CallInfo, req any, call client.CallInvoker) error {
+	err := call(ctx)
+	if err == nil && info.CacheHit {
+		m.cacheHits.WithLabelValues(info.Method.Name()).Inc()
+	}
+	return err
+}
+
+func (m *Collector) unary(ctx context.Context, info *client.CallInfo, req any, invoke client.UnaryInvoker) (any, error) {
+	m.begin(info, req)
+	start := time.Now()
+
+	resp, err := invoke(ctx)
+	m.finish(info.Method.Name(), start, err)
+	if err == nil {
+		m.received.WithLabelValues(info.Method.Name()).Add(float64(sizeOf(resp)))
+	}
+	return resp, err
+}
+
+func (m *Collector) stream(ctx context.Context, info *client.CallInfo, req any, open client.Streamer) (client.ClientStream, error) {
+	m.begin(info, req)
+	start := time.Now()
+
+	s, err := open(ctx)
+	if err != nil {
+		m.finish(info.Method.Name(), start, err)
+		return nil, err
+	}
+
+	ms := &meteredStream{
+		ClientStream: s,
+		m:            m,
+		method:       info.Method.Name(),
+		start:        start,
+		received:     m.received.WithLabelValues(info.Method.Name()),
+	}
+	// the call's context is cancelled once it stops reading, so this only
+	// ends streams abandoned before EOF
+	stop := context.AfterFunc(ctx, func() { ms.end(context.Cause(ctx)) })
+	ms.mu.Lock()
+	ms.stop = stop
+	ms.mu.Unlock()
+	return ms, nil
+}
+
+type meteredStream struct {
+	client.ClientStream
+	m        *Collector
+	method   string
+	start    time.Time
+	received prometheus.Counter
+
+	mu   sync.Mutex
+	stop func() bool
+	done bool
+}
+
+func (s *meteredStream) Recv() (any, error) {
+	msg, err := s.ClientStream.Recv()
+	if err == io.EOF {
+		s.end(nil)
+		return msg, err
+	}
+	if err != nil {
+		s.end(err)
+		return msg, err
+	}
+	s.received.Add(float64(sizeOf(msg)))
+	return msg, nil
+}
+
+func (s *meteredStream) end(err error) {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return
+	}
+	s.done = true
+	stop := s.stop
+	s.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+	s.m.finish(s.method, s.start, err)
+}
+
+func result(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case client.IsConnectionError(err):
+		return "connection_error"
+	default:
+		return "error"
+	}
+}
+
+func sizeOf(msg any) int {
+	if m, ok := msg.(interface{ SizeVT() int }); ok {
+		return m.SizeVT()
+	}
+	return 0
+}
@@ -0,0 +1,280 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/client"
+	"github.com/nonhumantrades/flowdb-go/memserver"
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// dial instruments cfg with a collector watching the client, then dials a
+// memserver seeded with table "t". inner interceptors run inside the
+// collector's.
+func dial(t *testing.T, cfg client.Config, inner ...client.UnaryInterceptor) (*client.Client, *Collector) {
+	t.Helper()
+	s := memserver.New()
+	t.Cleanup(func() { _ = s.Close() })
+
+	m := New()
+	m.Instrument(&cfg)
+	cfg.UnaryInterceptors = append(cfg.UnaryInterceptors, inner...)
+	cfg.Address, cfg.Dialer = "memserver", s.Dial
+	cfg.KeepaliveInterval = -1
+
+	c, err := client.Dial(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	m.Watch(c)
+
+	ctx := context.Background()
+	if _, err := c.CreateTable(ctx, "t"); err != nil {
+		t.Fatal(err)
+	}
+	rows := make([]*proto.Row, 10)
+	for i := range rows {
+		rows[i] = &proto.Row{Timestamp: timestamppb.New(time.Unix(int64(i), 0)), Data: []byte("row")}
+	}
+	if _, err := c.Insert(ctx, &proto.InsertRequest{TableName: "t", Rows: rows}); err != nil {
+		t.Fatal(err)
+	}
+	return c, m
+}
+
+// gather returns the values m exports, keyed by name and labels as in
+// `requests_total{method="GetTable",result="ok"}`. Histograms give their
+// sample count.
+func gather(t *testing.T, m *Collector) map[string]float64 {
+	t.Helper()
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatal(err)
+	}
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[string]float64)
+	for _, mf := range mfs {
+		name := strings.TrimPrefix(mf.GetName(), namespace+"_")
+		for _, mt := range mf.GetMetric() {
+			var labels []string
+			for _, l := range mt.GetLabel() {
+				labels = append(labels, fmt.Sprintf("%s=%q", l.GetName(), l.GetValue()))
+			}
+			sort.Strings(labels)
+			key := name + "{" + strings.Join(labels, ",") + "}"
+			switch {
+			case mt.Counter != nil:
+				out[key] = mt.GetCounter().GetValue()
+			case mt.Gauge != nil:
+				out[key] = mt.GetGauge().GetValue()
+			case mt.Histogram != nil:
+				out[key] = float64(mt.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return out
+}
+
+func checkValues(t *testing.T, got map[string]float64, want map[string]float64) {
+	t.Helper()
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+type noWait struct{}
+
+func (noWait) Next(int, time.Duration) (time.Duration, bool) { return 0, true }
+
+func TestRequests(t *testing.T) {
+	failFirst := func(ctx context.Context, info *client.CallInfo, req any, invoke client.UnaryInvoker) (any, error) {
+		if info.Method.Name() == "GetTable" && info.Attempt == 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return invoke(ctx)
+	}
+	c, m := dial(t, client.Config{PoolSize: 1, MaxRetriesPerCall: 2, Backoff: noWait{}}, failFirst)
+
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListTables(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DropTable(context.Background(), "missing"); err == nil {
+		t.Fatal("dropped a missing table")
+	}
+
+	got := gather(t, m)
+	checkValues(t, got, map[string]float64{
+		`requests_total{method="GetTable",result="connection_error"}`: 1,
+		`requests_total{method="GetTable",result="ok"}`:               1,
+		`requests_total{method="ListTables",result="ok"}`:             1,
+		`requests_total{method="DropTable",result="error"}`:           1,
+		`retries_total{method="GetTable"}`:                            1,
+		`request_duration_seconds{method="GetTable"}`:                 2,
+		`request_duration_seconds{method="ListTables"}`:               1,
+	})
+	for _, k := range []string{`sent_bytes_total{method="GetTable"}`, `received_bytes_total{method="GetTable"}`} {
+		if got[k] == 0 {
+			t.Errorf("%s not counted", k)
+		}
+	}
+	if problems, err := testutil.CollectAndLint(m); err != nil || len(problems) > 0 {
+		t.Fatalf("lint: %v %v", err, problems)
+	}
+}
+
+func TestStreams(t *testing.T) {
+	c, m := dial(t, client.Config{})
+	req := &proto.QueryRequest{TableName: "t", StreamOptions: &proto.StreamOptions{RowsPerChunk: client.Uint32(4)}}
+	if _, err := c.StreamQuery(context.Background(), client.NewStreamQueryParams().WithRequest(req)); err != nil {
+		t.Fatal(err)
+	}
+
+	// a stream the caller stops reading didn't end well
+	stop := errors.New("stop")
+	p := client.NewStreamQueryParams().WithRequest(req).WithOnRow(func(*proto.Row) error { return stop })
+	if _, err := c.StreamQuery(context.Background(), p); !errors.Is(err, stop) {
+		t.Fatalf("got %v, want the callback's error", err)
+	}
+
+	var got map[string]float64
+	deadline := time.Now().Add(time.Second)
+	for {
+		got = gather(t, m)
+		if got[`request_duration_seconds{method="StreamQuery"}`] == 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	checkValues(t, got, map[string]float64{
+		`requests_total{method="StreamQuery",result="ok"}`:       1,
+		`requests_total{method="StreamQuery",result="canceled"}`: 1,
+		`request_duration_seconds{method="StreamQuery"}`:         2,
+	})
+	if got[`received_bytes_total{method="StreamQuery"}`] == 0 {
+		t.Error("stream bytes not counted")
+	}
+}
+
+func TestPoolGauges(t *testing.T) {
+	c, m := dial(t, client.Config{PoolSize: 2, StreamPoolSize: 1})
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	p := client.NewStreamQueryParams().WithRequest(&proto.QueryRequest{TableName: "t"}).WithOnRow(func(*proto.Row) error {
+		select {
+		case <-started:
+		default:
+			close(started)
+		}
+		<-release
+		return nil
+	})
+	go func() {
+		_, err := c.StreamQuery(context.Background(), p)
+		done <- err
+	}()
+	<-started
+
+	checkValues(t, gather(t, m), map[string]float64{
+		`pool_conns{lane="unary"}`:          2,
+		`pool_conns{lane="stream"}`:         1,
+		`pool_busy_conns{lane="unary"}`:     0,
+		`pool_busy_conns{lane="stream"}`:    1,
+		`pool_waiting_calls{lane="stream"}`: 0,
+	})
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	checkValues(t, gather(t, m), map[string]float64{`pool_busy_conns{lane="stream"}`: 0})
+}
+
+func TestHedgeCounters(t *testing.T) {
+	slowFirst := func(ctx context.Context, info *client.CallInfo, req any, invoke client.UnaryInvoker) (any, error) {
+		if info.Method.Name() == "GetTable" && !info.Hedge {
+			select {
+			case <-time.After(200 * time.Millisecond):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return invoke(ctx)
+	}
+	c, m := dial(t, client.Config{PoolSize: 2, Hedge: client.HedgeConfig{Delay: 10 * time.Millisecond}}, slowFirst)
+
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	checkValues(t, gather(t, m), map[string]float64{
+		`hedged_calls_total{method="GetTable"}`: 1,
+		`hedge_wins_total{method="GetTable"}`:   1,
+	})
+}
+
+func TestConnStateChain(t *testing.T) {
+	var seen []client.ConnStateChange
+	failFirst := func(ctx context.Context, info *client.CallInfo, req any, invoke client.UnaryInvoker) (any, error) {
+		if info.Method.Name() == "GetTable" && info.Attempt == 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return invoke(ctx)
+	}
+	c, m := dial(t, client.Config{
+		PoolSize:          1,
+		MaxRetriesPerCall: 2,
+		Backoff:           noWait{},
+		OnConnStateChange: func(ev client.ConnStateChange) { seen = append(seen, ev) },
+	}, failFirst)
+
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	checkValues(t, gather(t, m), map[string]float64{
+		`broken_conns_total{lane="unary"}`: 1,
+		`reconnects_total{lane="unary"}`:   1,
+	})
+	broken := 0
+	for _, ev := range seen {
+		if ev.To == client.ConnBroken {
+			broken++
+		}
+	}
+	if broken != 1 {
+		t.Fatalf("hook set before Instrument saw %+v", seen)
+	}
+}
+
+func TestCacheHits(t *testing.T) {
+	c, m := dial(t, client.Config{Cache: client.CacheConfig{MaxBytes: 1 << 20, Horizon: time.Hour}})
+	req := &proto.QueryRequest{
+		TableName:     "t",
+		FilterOptions: &proto.FilterOptions{From: timestamppb.New(time.Unix(0, 0)), To: timestamppb.New(time.Unix(100, 0))},
+	}
+	for range 3 {
+		if _, err := c.Query(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkValues(t, gather(t, m), map[string]float64{
+		`cache_hits_total{method="Query"}`:           2,
+		`requests_total{method="Query",result="ok"}`: 1,
+	})
+}
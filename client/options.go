@@ -0,0 +1,134 @@
+package client
+
+import (
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// CallOption changes the behaviour of a single Client call.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout     time.Duration
+	maxRetries  int
+	compression *proto.CompressionMethod
+	affinity    *uint64
+	lane        Lane
+	failFast    bool
+	hedgeDelay  *time.Duration
+	// second request of a hedged call
+	hedge bool
+	// retry connection errors of a method that isn't idempotent
+	idempotent bool
+	// told whenever the call checks a conn out and returns it
+	onConn func(held bool)
+
+	// table the call touches, for per-table limits
+	table string
+}
+
+// Lane selects the set of pooled connections a call runs on.
+type Lane int
+
+const (
+	// streaming RPCs use the stream lane, everything else the shared pool
+	LaneDefault Lane = iota
+	LaneUnary
+	// only distinct from LaneUnary when Config.StreamPoolSize is set
+	LaneStream
+)
+
+// WithTimeout bounds each attempt of the call, streams included. It
+// overrides Config.Timeout and applies even when ctx has a deadline.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) { o.timeout = d }
+}
+
+// WithMaxRetries overrides Config.MaxRetriesPerCall for the call.
+func WithMaxRetries(n int) CallOption {
+	return func(o *callOptions) { o.maxRetries = n }
+}
+
+// WithCompression sets the compression the server should use for the
+// payload of Query, StreamQuery and Backup, overriding the request.
+func WithCompression(m proto.CompressionMethod) CallOption {
+	return func(o *callOptions) { o.compression = &m }
+}
+
+// WithAffinity pins the call to the pooled connection selected by key, so
+// calls sharing a key are sent over the same connection while it is
+// healthy. Keys are spread over the first PoolSize connections only, so a
+// key keeps its connection while the pool grows. Retries move on to the
+// following connections.
+func WithAffinity(key string) CallOption {
+	return func(o *callOptions) {
+		h := xxhash.Sum64String(key)
+		o.affinity = &h
+	}
+}
+
+// WithLane runs the call on the given lane, e.g. a small StreamQuery on the
+// shared pool or a heavy Query next to the bulk transfers.
+func WithLane(l Lane) CallOption {
+	return func(o *callOptions) { o.lane = l }
+}
+
+// WithFailFast makes the call fail with a *LimitError rather than wait when
+// it is over a client-side limit, or wait when false, overriding
+// Config.FailFastOnLimit.
+func WithFailFast(failFast bool) CallOption {
+	return func(o *callOptions) { o.failFast = failFast }
+}
+
+// WithHedgeDelay sends the call again on another conn when it hasn't
+// answered after d, overriding Config.Hedge (0 = don't hedge). Only RPCs
+// listed in HedgeConfig.Methods are hedged.
+func WithHedgeDelay(d time.Duration) CallOption {
+	return func(o *callOptions) { o.hedgeDelay = &d }
+}
+
+func (c *Client) callOptions(opts []CallOption) *callOptions {
+	o := &callOptions{
+		maxRetries: c.cfg.MaxRetriesPerCall,
+		failFast:   c.cfg.FailFastOnLimit,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.maxRetries <= 0 {
+		o.maxRetries = 1
+	}
+	return o
+}
+
+func (c *Client) tableOptions(table string, opts []CallOption) *callOptions {
+	o := c.callOptions(opts)
+	o.table = table
+	return o
+}
+
+// hedging returns the options of the second request of a hedged call,
+// pinned to the conn after the first one's when the call has affinity.
+func (o *callOptions) hedging() *callOptions {
+	h := *o
+	h.hedge = true
+	if o.affinity != nil {
+		a := *o.affinity + 1
+		h.affinity = &a
+	}
+	return &h
+}
+
+// callInfo describes the whole call to m for the call interceptors.
+func (o *callOptions) callInfo(m Method) *CallInfo {
+	return &CallInfo{Method: m, Table: o.table}
+}
+
+func (o *callOptions) compressionOr(m proto.CompressionMethod) proto.CompressionMethod {
+	if o.compression != nil {
+		return *o.compression
+	}
+	return m
+}
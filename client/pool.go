@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// connPool hands out pooled conns for exclusive use. A drpc conn runs one
+// RPC at a time, so a call checks a conn out for as long as it needs it and
+// others take an idle conn, grow the pool up to its limit, or wait.
+type connPool struct {
+	mu      sync.Mutex
+	conns   []*conn
+	idle    []*conn // most recently returned last
+	waiters []*waiter
+	max     int
+	// conns WithAffinity keys are spread over: the initial ones, so a key
+	// keeps its conn as the pool grows
+	slots   int
+	newConn func(i int) *conn
+
+	acquired uint64
+	waited   uint64
+	waitTime time.Duration
+	maxWait  time.Duration
+}
+
+type waiter struct {
+	want *conn // nil = any conn
+	ch   chan *conn
+}
+
+func newConnPool(size, max int, newConn func(i int) *conn) *connPool {
+	p := &connPool{max: max, slots: size, newConn: newConn}
+	for i := 0; i < size; i++ {
+		w := newConn(i)
+		p.conns = append(p.conns, w)
+		p.idle = append(p.idle, w)
+	}
+	return p
+}
+
+// get checks out an idle conn, or the conn at index want%slots when want
+// is set, waiting until one is returned or ctx is done.
+func (p *connPool) get(ctx context.Context, want *uint64) (*conn, error) {
+	p.mu.Lock()
+
+	var target *conn
+	if want != nil {
+		target = p.conns[int(*want%uint64(p.slots))]
+		if i := slices.Index(p.idle, target); i >= 0 {
+			p.idle = slices.Delete(p.idle, i, i+1)
+			p.acquired++
+			p.mu.Unlock()
+			return target, nil
+		}
+	} else {
+		if n := len(p.idle); n > 0 {
+			w := p.idle[n-1]
+			p.idle = p.idle[:n-1]
+			p.acquired++
+			p.mu.Unlock()
+			return w, nil
+		}
+		if len(p.conns) < p.max {
+			w := p.newConn(len(p.conns))
+			p.conns = append(p.conns, w)
+			p.acquired++
+			p.mu.Unlock()
+			return w, nil
+		}
+	}
+
+	wt := &waiter{want: target, ch: make(chan *conn, 1)}
+	p.waiters = append(p.waiters, wt)
+	p.mu.Unlock()
+
+	start := time.Now()
+	select {
+	case w := <-wt.ch:
+		p.recordWait(time.Since(start))
+		return w, nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		if i := slices.Index(p.waiters, wt); i >= 0 {
+			p.waiters = slices.Delete(p.waiters, i, i+1)
+			p.mu.Unlock()
+			return nil, ctx.Err()
+		}
+		p.mu.Unlock()
+		// handed a conn while giving up; pass it on
+		p.put(<-wt.ch)
+		return nil, ctx.Err()
+	}
+}
+
+// tryGet checks out an idle conn, or a new one while the pool may grow, and
+// returns nil instead of waiting.
+func (p *connPool) tryGet() *conn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n := len(p.idle); n > 0 {
+		w := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.acquired++
+		return w
+	}
+	if len(p.conns) < p.max {
+		w := p.newConn(len(p.conns))
+		p.conns = append(p.conns, w)
+		p.acquired++
+		return w
+	}
+	return nil
+}
+
+func (p *connPool) recordWait(d time.Duration) {
+	p.mu.Lock()
+	p.acquired++
+	p.waited++
+	p.waitTime += d
+	p.maxWait = max(p.maxWait, d)
+	p.mu.Unlock()
+}
+
+// put returns w to the pool, handing it straight to the oldest waiter that
+// accepts it. Disconnected conns are preferred last so retries land on a
+// live conn first. A conn flagged to leave its endpoint is redialed first.
+func (p *connPool) put(w *conn) {
+	w.redialIfLeaving()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, wt := range p.waiters {
+		if wt.want == nil || wt.want == w {
+			p.waiters = slices.Delete(p.waiters, i, i+1)
+			wt.ch <- w
+			return
+		}
+	}
+
+	if w.connectedTo() == nil {
+		p.idle = slices.Insert(p.idle, 0, w)
+		return
+	}
+	p.idle = append(p.idle, w)
+}
+
+// take checks w out if it is idle, without waiting.
+func (p *connPool) take(w *conn) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i := slices.Index(p.idle, w)
+	if i < 0 {
+		return false
+	}
+	p.idle = slices.Delete(p.idle, i, i+1)
+	return true
+}
+
+// all returns every conn in the pool, checked out or not.
+func (p *connPool) all() []*conn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.conns)
+}
+
+type PoolStats struct {
+	// conns in the pool and how many are checked out
+	Size  int
+	InUse int
+	Idle  int
+	// calls currently waiting for a conn
+	Waiting int
+	// checkouts so far, and how many of them had to wait
+	Acquired uint64
+	Waited   uint64
+	// total and longest time spent waiting for a conn
+	WaitTime    time.Duration
+	MaxWaitTime time.Duration
+	// conns whose circuit breaker is not closed
+	OpenBreakers int
+}
+
+func (p *connPool) stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var open int
+	for _, w := range p.conns {
+		if w.breaker.current() != BreakerClosed {
+			open++
+		}
+	}
+
+	return PoolStats{
+		Size:         len(p.conns),
+		InUse:        len(p.conns) - len(p.idle),
+		Idle:         len(p.idle),
+		Waiting:      len(p.waiters),
+		Acquired:     p.acquired,
+		Waited:       p.waited,
+		WaitTime:     p.waitTime,
+		MaxWaitTime:  p.maxWait,
+		OpenBreakers: open,
+	}
+}
+
+// PoolStats reports connection checkout metrics of the shared pool.
+func (c *Client) PoolStats() PoolStats {
+	return c.pool.stats()
+}
+
+// StreamPoolStats reports checkout metrics of the stream lane, which is
+// empty unless Config.StreamPoolSize is set.
+func (c *Client) StreamPoolStats() PoolStats {
+	if c.streamPool == nil {
+		return PoolStats{}
+	}
+	return c.streamPool.stats()
+}
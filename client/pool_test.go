@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+// newBarePool returns a pool of conns that are never dialed.
+func newBarePool(size, max int) *connPool {
+	cfg := &BreakerConfig{}
+	cfg.applyDefaults()
+	return newConnPool(size, max, func(i int) *conn { return &conn{id: i, breaker: newBreaker(cfg, nil)} })
+}
+
+func TestPoolAffinityStableWhileGrowing(t *testing.T) {
+	p := newBarePool(2, 4)
+	ctx := context.Background()
+	want := uint64(3)
+
+	w, err := p.get(ctx, &want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.id != 1 {
+		t.Fatalf("key 3 got conn %d, want 1", w.id)
+	}
+	p.put(w)
+
+	// grow to the limit
+	var out []*conn
+	for w := p.tryGet(); w != nil; w = p.tryGet() {
+		out = append(out, w)
+	}
+	if len(out) != 4 {
+		t.Fatalf("grew to %d conns, want 4", len(out))
+	}
+	for _, w := range out {
+		p.put(w)
+	}
+
+	for range 10 {
+		w, err := p.get(ctx, &want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.id != 1 {
+			t.Fatalf("key 3 moved to conn %d after the pool grew", w.id)
+		}
+		p.put(w)
+	}
+}
+
+func TestPoolAffinityWaitsForItsConn(t *testing.T) {
+	p := newBarePool(2, 4)
+	want := uint64(0)
+	pinned, _ := p.get(context.Background(), &want)
+
+	// other calls grow the pool rather than wait
+	if w := p.tryGet(); w == nil || w == pinned {
+		t.Fatal("no other conn")
+	}
+	if w := p.tryGet(); w == nil || w.id != 2 {
+		t.Fatal("pool did not grow")
+	}
+
+	got := make(chan *conn)
+	go func() {
+		w, _ := p.get(context.Background(), &want)
+		got <- w
+	}()
+	eventually(t, func() bool { return p.stats().Waiting == 1 }, "affine call not waiting")
+	p.put(pinned)
+	if w := <-got; w != pinned {
+		t.Fatalf("affine call got conn %d", w.id)
+	}
+}
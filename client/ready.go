@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrNoConnection = errors.New("no connection could be made")
+
+// connect dials every disconnected conn of the shared pool once and returns
+// how many are connected, with the last dial error.
+func (c *Client) connect(ctx context.Context) (int, error) {
+	var ready int
+	var lastErr error
+	for _, w := range c.pool.all() {
+		if _, err := w.ensureClient(ctx); err != nil {
+			lastErr = err
+			continue
+		}
+		ready++
+	}
+	return ready, lastErr
+}
+
+// WaitForReady blocks until at least n pooled connections are established,
+// redialing with the configured backoff, or until ctx is done. n is capped
+// at the pool size.
+func (c *Client) WaitForReady(ctx context.Context, n int) error {
+	n = max(1, min(n, len(c.pool.all())))
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		ready, err := c.connect(ctx)
+		if ready >= n {
+			return nil
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("%w: %d of %d connections ready: %w", ErrNoConnection, ready, n, errors.Join(ctxErr, err))
+		}
+
+		wait, ok := c.cfg.Backoff.Next(attempt, time.Since(start))
+		if !ok {
+			// the policy's MaxElapsed only bounds calls; keep waiting on ctx
+			wait = c.cfg.ReconnectInterval
+		}
+		if sleepErr := sleepCtx(ctx, wait); sleepErr != nil {
+			return fmt.Errorf("%w: %d of %d connections ready: %w", ErrNoConnection, ready, n, errors.Join(sleepErr, err))
+		}
+	}
+}
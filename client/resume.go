@@ -0,0 +1,112 @@
+package client
+
+import (
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// queryCursor tracks the rows a StreamQuery has handed to its callbacks,
+// so the query can be sent again for the rest after the connection drops.
+type queryCursor struct {
+	// rows are tracked for resuming, not just counted
+	track   bool
+	reverse bool
+	// Limit of the original request
+	limit int64
+
+	delivered uint64
+	batches   uint32
+	// the query was sent again for the rows after last
+	resumed bool
+	// timestamp of the last row delivered, and the data hashes of the rows
+	// delivered with it, which the server may send in another order
+	last   int64
+	atLast map[uint64]int
+
+	// rows of the current attempt at last that were delivered before
+	skip map[uint64]int
+}
+
+func newQueryCursor(req *proto.QueryRequest, track bool) *queryCursor {
+	return &queryCursor{
+		track:   track,
+		reverse: req.FilterOptions.GetReverse() && !req.Head,
+		limit:   req.FilterOptions.GetLimit(),
+	}
+}
+
+// resume returns req narrowed to the rows not delivered yet. The range
+// starts at, or in reverse ends after, the last timestamp delivered, and
+// the rows seen before at that timestamp are dropped again by filter.
+func (q *queryCursor) resume(req *proto.QueryRequest) *proto.QueryRequest {
+	q.skip = nil
+	if q.delivered == 0 {
+		return req
+	}
+
+	q.resumed = true
+	req = req.CloneVT()
+	if req.FilterOptions == nil {
+		req.FilterOptions = &proto.FilterOptions{}
+	}
+	f := req.FilterOptions
+	if q.reverse {
+		f.To = timestamppb.New(time.Unix(0, q.last+1))
+	} else {
+		f.From = timestamppb.New(time.Unix(0, q.last))
+	}
+	q.skip = make(map[uint64]int, len(q.atLast))
+	var seen int64
+	for h, n := range q.atLast {
+		q.skip[h] = n
+		seen += int64(n)
+	}
+	if q.limit > 0 {
+		// never 0, which would mean the server's default
+		limit := q.limit - int64(q.delivered) + seen
+		f.Limit = &limit
+	}
+	return req
+}
+
+// filter returns the rows of a batch that weren't delivered by an earlier
+// attempt, up to the limit of the original request.
+func (q *queryCursor) filter(rows []*proto.Row) []*proto.Row {
+	if len(q.skip) > 0 {
+		kept := make([]*proto.Row, 0, len(rows))
+		for _, r := range rows {
+			h := xxhash.Sum64(r.Data)
+			if q.skip[h] > 0 && r.GetTimestamp().AsTime().UnixNano() == q.last {
+				q.skip[h]--
+				continue
+			}
+			kept = append(kept, r)
+		}
+		rows = kept
+	}
+	if q.limit > 0 {
+		rows = rows[:min(len(rows), int(q.limit-int64(q.delivered)))]
+	}
+	return rows
+}
+
+// advance records rows as delivered.
+func (q *queryCursor) advance(rows []*proto.Row) {
+	q.batches++
+	if !q.track {
+		q.delivered += uint64(len(rows))
+		return
+	}
+	for _, r := range rows {
+		ts := r.GetTimestamp().AsTime().UnixNano()
+		if q.delivered == 0 || ts != q.last {
+			q.last = ts
+			q.atLast = make(map[uint64]int)
+		}
+		q.atLast[xxhash.Sum64(r.Data)]++
+		q.delivered++
+	}
+}
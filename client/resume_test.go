@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/nonhumantrades/flowdb-go/faultproxy"
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// shiftBatches is a stream interceptor that adds 10 to the index of every
+// batch and sends an empty batch first.
+func shiftBatches(ctx context.Context, info *CallInfo, req any, open Streamer) (ClientStream, error) {
+	s, err := open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &shiftedStream{ClientStream: s}, nil
+}
+
+type shiftedStream struct {
+	ClientStream
+	sentEmpty bool
+	pending   any
+}
+
+func (s *shiftedStream) Recv() (any, error) {
+	if msg := s.pending; msg != nil {
+		s.pending = nil
+		return msg, nil
+	}
+	msg, err := s.ClientStream.Recv()
+	if err != nil {
+		return msg, err
+	}
+	b, ok := msg.(*proto.StreamQueryChunk).Chunk.(*proto.StreamQueryChunk_Batch)
+	if !ok {
+		return msg, nil
+	}
+	b.Batch.Index += 10
+	if !s.sentEmpty {
+		s.sentEmpty = true
+		s.pending = msg
+		return &proto.StreamQueryChunk{Chunk: &proto.StreamQueryChunk_Batch{Batch: &proto.StreamQueryBatch{Index: 9}}}, nil
+	}
+	return msg, nil
+}
+
+func TestStreamBatchIndexes(t *testing.T) {
+	for _, resume := range []bool{false, true} {
+		c := dialTest(t, newTestServer(t), Config{StreamInterceptors: []StreamInterceptor{shiftBatches}})
+		seed(t, c, "t", "", 250)
+
+		var indexes []uint32
+		var sizes []int
+		req := &proto.QueryRequest{TableName: "t", StreamOptions: &proto.StreamOptions{RowsPerChunk: Uint32(100)}}
+		p := NewStreamQueryParams().WithRequest(req).WithResume(resume).WithOnBatch(func(i uint32, rows []*proto.Row) error {
+			indexes = append(indexes, i)
+			sizes = append(sizes, len(rows))
+			return nil
+		})
+		rows, resp, err := streamAll(t, c, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRows(t, rows, 0, 250, false)
+		if resp.Count != 250 {
+			t.Fatalf("resume %v: count %d", resume, resp.Count)
+		}
+
+		want, wantSizes := []uint32{9, 10, 11, 12}, []int{0, 100, 100, 50}
+		if resume {
+			// renumbered across attempts, skipping empty batches
+			want, wantSizes = []uint32{0, 1, 2}, []int{100, 100, 50}
+		}
+		if !slices.Equal(indexes, want) || !slices.Equal(sizes, wantSizes) {
+			t.Fatalf("resume %v: batches %v of %v rows, want %v of %v", resume, indexes, sizes, want, wantSizes)
+		}
+	}
+}
+
+// footerStream adds 1000 to the row count of the footer, as a server that
+// counts rows it didn't send would.
+type footerStream struct {
+	ClientStream
+}
+
+func (s footerStream) Recv() (any, error) {
+	msg, err := s.ClientStream.Recv()
+	if f, ok := msg.(*proto.StreamQueryChunk).GetChunk().(*proto.StreamQueryChunk_Footer); ok && err == nil {
+		f.Footer.Count += 1000
+	}
+	return msg, err
+}
+
+func TestStreamCountFromFooter(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{StreamInterceptors: []StreamInterceptor{
+		func(ctx context.Context, info *CallInfo, req any, open Streamer) (ClientStream, error) {
+			s, err := open(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return footerStream{s}, nil
+		},
+	}})
+	seed(t, c, "t", "", 10)
+
+	// the server's count stands unless a resume cut its stream short
+	for _, resume := range []bool{false, true} {
+		_, resp, err := streamAll(t, c, NewStreamQueryParams().WithRequest(rangeQuery("t", 0, 10)).WithResume(resume))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Count != 1010 {
+			t.Fatalf("resume %v: count %d, want the footer's", resume, resp.Count)
+		}
+	}
+}
+
+func TestStreamResumeReverseLimit(t *testing.T) {
+	e := newFaultEnv(t)
+	c := e.dial(t, Config{})
+	seed(t, c, "t", "", 5000)
+	limit, reverse := int64(3000), true
+	req := &proto.QueryRequest{
+		TableName:     "t",
+		FilterOptions: &proto.FilterOptions{Reverse: &reverse, Limit: &limit},
+		StreamOptions: &proto.StreamOptions{RowsPerChunk: Uint32(100)},
+	}
+
+	e.fault(t, c, faultproxy.Faults{DropAfterBytes: 64 << 10})
+	var indexes []uint32
+	p := NewStreamQueryParams().WithRequest(req).WithResume(true).WithOnBatch(func(i uint32, _ []*proto.Row) error {
+		indexes = append(indexes, i)
+		return nil
+	})
+	rows, resp, err := streamAll(t, c, p, WithMaxRetries(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRows(t, rows, 2000, 5000, true)
+	if resp.Count != 3000 {
+		t.Fatalf("count %d", resp.Count)
+	}
+	for i, idx := range indexes {
+		if idx != uint32(i) {
+			t.Fatalf("batch %d has index %d", i, idx)
+		}
+	}
+}
+
+func TestQueryCursorSameTimestamp(t *testing.T) {
+	// three rows share the last timestamp; two of them were delivered
+	limit := int64(10)
+	req := &proto.QueryRequest{TableName: "t", FilterOptions: &proto.FilterOptions{Limit: &limit}}
+	cur := newQueryCursor(req, true)
+	first := []*proto.Row{
+		{Timestamp: ts(1), Data: []byte("a")},
+		{Timestamp: ts(2), Data: []byte("b")},
+		{Timestamp: ts(2), Data: []byte("c")},
+	}
+	cur.advance(cur.filter(first))
+
+	next := cur.resume(req)
+	if !next.FilterOptions.From.AsTime().Equal(ts(2).AsTime()) {
+		t.Fatalf("resumed from %v", next.FilterOptions.From.AsTime())
+	}
+	// the two rows at 2 are sent again and dropped
+	if got := next.FilterOptions.GetLimit(); got != 9 {
+		t.Fatalf("resumed limit %d, want 9", got)
+	}
+	// sent in another order
+	again := []*proto.Row{
+		{Timestamp: ts(2), Data: []byte("d")},
+		{Timestamp: ts(2), Data: []byte("c")},
+		{Timestamp: ts(2), Data: []byte("b")},
+		{Timestamp: ts(3), Data: []byte("e")},
+	}
+	rows := cur.filter(again)
+	if len(rows) != 2 || string(rows[0].Data) != "d" || string(rows[1].Data) != "e" {
+		t.Fatalf("filtered %v", rows)
+	}
+	if req.FilterOptions.GetLimit() != 10 || req.FilterOptions.From != nil {
+		t.Fatal("original request changed")
+	}
+}
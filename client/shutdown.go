@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrShutdown is returned by calls started after Shutdown.
+var ErrShutdown = errors.New("client is shutting down")
+
+// Operation is a call in flight, from its start until its result or last
+// stream message is handled.
+type Operation struct {
+	Method  string
+	Table   string
+	Started time.Time
+}
+
+// ShutdownError is returned by Shutdown when ctx ended before every call
+// finished. It matches ctx's error with errors.Is.
+type ShutdownError struct {
+	// calls still running when the connections were closed
+	Interrupted []Operation
+	Err         error
+}
+
+func (e *ShutdownError) Error() string {
+	names := make([]string, len(e.Interrupted))
+	for i, op := range e.Interrupted {
+		names[i] = op.Method
+		if op.Table != "" {
+			names[i] += " " + op.Table
+		}
+	}
+	return fmt.Sprintf("shutdown: %v: %d calls interrupted (%s)", e.Err, len(e.Interrupted), strings.Join(names, ", "))
+}
+
+func (e *ShutdownError) Unwrap() error { return e.Err }
+
+// tracker keeps the calls in flight so Shutdown can wait for them.
+type tracker struct {
+	mu      sync.Mutex
+	closing bool
+	ops     map[*Operation]struct{}
+	// closed once closing and no call is left
+	drained chan struct{}
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		ops:     make(map[*Operation]struct{}),
+		drained: make(chan struct{}),
+	}
+}
+
+// begin records a call and returns the func that ends it, or ErrShutdown
+// once Shutdown has been called.
+func (t *tracker) begin(m Method, table string) (func(), error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closing {
+		return nil, ErrShutdown
+	}
+	op := &Operation{Method: m.Name(), Table: table, Started: time.Now()}
+	t.ops[op] = struct{}{}
+
+	var once sync.Once
+	return func() { once.Do(func() { t.end(op) }) }, nil
+}
+
+func (t *tracker) end(op *Operation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.ops, op)
+	if t.closing && len(t.ops) == 0 {
+		close(t.drained)
+	}
+}
+
+// close stops new calls and returns a channel closed once none are left.
+func (t *tracker) close() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.closing {
+		t.closing = true
+		if len(t.ops) == 0 {
+			close(t.drained)
+		}
+	}
+	return t.drained
+}
+
+// running returns the calls in flight, oldest first.
+func (t *tracker) running() []Operation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]Operation, 0, len(t.ops))
+	for op := range t.ops {
+		out = append(out, *op)
+	}
+	slices.SortFunc(out, func(a, b Operation) int { return a.Started.Compare(b.Started) })
+	return out
+}
+
+// Shutdown stops the client gracefully: calls started afterwards fail with
+// ErrShutdown, calls and streams in flight run until they finish or ctx is
+// done, and then the connections are closed as by Close. If ctx ends first
+// the error is a *ShutdownError listing the calls that were cut off.
+func (c *Client) Shutdown(ctx context.Context) error {
+	drained := c.tracker.close()
+
+	var interrupted []Operation
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		interrupted = c.tracker.running()
+	}
+
+	err := c.Close()
+	if len(interrupted) > 0 {
+		return &ShutdownError{Interrupted: interrupted, Err: ctx.Err()}
+	}
+	return err
+}
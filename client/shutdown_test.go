@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShutdownDrains(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{})
+	seed(t, c, "t", "", 10)
+	release := holdStream(t, c, "t")
+
+	done := make(chan error, 1)
+	go func() { done <- c.Shutdown(context.Background()) }()
+
+	// new calls are turned away while the stream drains
+	eventually(t, func() bool {
+		_, err := c.ListTables(context.Background())
+		return errors.Is(err, ErrShutdown)
+	}, "call accepted after Shutdown")
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned %v before the stream ended", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if err := release(); err != nil {
+		t.Fatalf("stream cut by Shutdown: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestShutdownInterrupts(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{})
+	seed(t, c, "t", "", 10)
+	release := holdStream(t, c, "t")
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := c.Shutdown(ctx)
+	var se *ShutdownError
+	if !errors.As(err, &se) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want a ShutdownError", err)
+	}
+	if len(se.Interrupted) != 1 || se.Interrupted[0].Method != "StreamQuery" || se.Interrupted[0].Table != "t" {
+		t.Fatalf("interrupted %+v", se.Interrupted)
+	}
+	if want := "1 calls interrupted (StreamQuery t)"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("error %q doesn't say %q", err, want)
+	}
+}
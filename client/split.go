@@ -0,0 +1,387 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// rows a sub-range should hold at least, judged by Table.row_count
+	DefaultMinRowsPerPart = 10_000
+	// bytes of rows SplitQuery buffers ahead of the caller
+	DefaultSplitBufferBytes = 64 << 20
+)
+
+type SplitQueryParams struct {
+	req            *proto.QueryRequest
+	parts          int
+	minRowsPerPart uint64
+	maxBufferBytes int
+	resume         bool
+	onRow          func(*proto.Row) error
+}
+
+func NewSplitQueryParams() *SplitQueryParams {
+	return &SplitQueryParams{
+		minRowsPerPart: DefaultMinRowsPerPart,
+		maxBufferBytes: DefaultSplitBufferBytes,
+		onRow:          func(*proto.Row) error { return nil },
+	}
+}
+
+func (p *SplitQueryParams) WithRequest(req *proto.QueryRequest) *SplitQueryParams {
+	p.req = req
+	return p
+}
+
+func (p *SplitQueryParams) WithOnRow(onRow func(*proto.Row) error) *SplitQueryParams {
+	p.onRow = onRow
+	return p
+}
+
+// WithParts sets the sub-ranges the query is split into (default = the
+// connections its lane may open).
+func (p *SplitQueryParams) WithParts(n int) *SplitQueryParams {
+	p.parts = n
+	return p
+}
+
+// WithMinRowsPerPart lowers the number of parts for small tables so each
+// holds about n rows (default = DefaultMinRowsPerPart, 0 = off).
+func (p *SplitQueryParams) WithMinRowsPerPart(n uint64) *SplitQueryParams {
+	p.minRowsPerPart = n
+	return p
+}
+
+// WithMaxBufferBytes bounds the rows held for sub-ranges the caller hasn't
+// reached yet (default = DefaultSplitBufferBytes).
+func (p *SplitQueryParams) WithMaxBufferBytes(n int) *SplitQueryParams {
+	p.maxBufferBytes = n
+	return p
+}
+
+// WithResume resumes every sub-range after a connection loss, as
+// StreamQueryParams.WithResume.
+func (p *SplitQueryParams) WithResume(resume bool) *SplitQueryParams {
+	p.resume = resume
+	return p
+}
+
+// SplitQuery runs a StreamQuery as several queries over consecutive parts
+// of its time range, at the same time on different pooled connections,
+// and hands the rows to onRow in the order of the request, reverse
+// included. A range left open is bounded by the table's min and max
+// timestamps. A Limit above 0 is applied to the whole query; with a Limit
+// of 0 every part gets the server's default limit. Head queries, whose
+// limit every part would apply again, run as a single StreamQuery.
+// Duration in the result is the wall time of the whole query.
+func (c *Client) SplitQuery(ctx context.Context, p *SplitQueryParams, opts ...CallOption) (*proto.QueryResponse, error) {
+	if p.req == nil {
+		return nil, errors.New("request is required")
+	}
+	start := time.Now()
+	req := p.req
+	o := c.tableOptions(req.TableName, opts)
+
+	var ranges []timeRange
+	if !req.Head {
+		var err error
+		if ranges, err = c.splitRanges(ctx, p, o, opts); err != nil {
+			return nil, err
+		}
+	}
+	if len(ranges) <= 1 {
+		return c.StreamQuery(ctx, NewStreamQueryParams().WithRequest(req).WithOnRow(p.onRow).WithResume(p.resume), opts...)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	run := func(m *splitMerger, i int) {
+		r := ranges[i]
+		sub := req.CloneVT()
+		if sub.FilterOptions == nil {
+			sub.FilterOptions = &proto.FilterOptions{}
+		}
+		sub.FilterOptions.From = timestamppb.New(time.Unix(0, r.from))
+		sub.FilterOptions.To = timestamppb.New(time.Unix(0, r.to))
+
+		params := NewStreamQueryParams().WithRequest(sub).WithResume(p.resume).
+			WithOnBatch(func(_ uint32, rows []*proto.Row) error {
+				return m.push(i, rows)
+			})
+		// parts pinned to one conn would wait for each other
+		subOpts := append(slices.Clip(opts), func(o *callOptions) {
+			o.affinity = nil
+			o.onConn = func(held bool) { m.hold(i, held) }
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.StreamQuery(ctx, params, subOpts...)
+			m.finish(i, resp, err)
+		}()
+	}
+	m := newSplitMerger(len(ranges), p.maxBufferBytes, c.laneFor(methodStreamQuery, o).max, run)
+	defer func() {
+		cancel()
+		m.stop()
+		wg.Wait()
+	}()
+	m.start()
+
+	resp := &proto.QueryResponse{TableName: req.TableName, Prefix: req.Prefix, Compression: req.Compression}
+	limit := req.FilterOptions.GetLimit()
+	for i := range ranges {
+		for {
+			rows, part, err := m.next(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+			if part != nil {
+				// a part cut off by the limit means the whole query was
+				resp.TruncatedByLimit = resp.TruncatedByLimit || part.TruncatedByLimit
+				resp.CompressedBytes += part.CompressedBytes
+				resp.UncompressedBytes += part.UncompressedBytes
+				resp.Compression = part.Compression
+				break
+			}
+			for _, r := range rows {
+				if limit > 0 && int64(resp.Count) >= limit {
+					// a row past the limit
+					resp.TruncatedByLimit = true
+					resp.Duration = uint64(time.Since(start))
+					return resp, nil
+				}
+				if err := p.onRow(r); err != nil {
+					return nil, err
+				}
+				resp.Count++
+			}
+		}
+	}
+	resp.Duration = uint64(time.Since(start))
+	return resp, nil
+}
+
+type timeRange struct {
+	from, to int64
+}
+
+// splitRanges divides the request's range into the parts SplitQuery runs,
+// in the order their rows are delivered.
+func (c *Client) splitRanges(ctx context.Context, p *SplitQueryParams, o *callOptions, opts []CallOption) ([]timeRange, error) {
+	t, err := c.GetTable(ctx, p.req.TableName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if t.MinTimestamp == nil || t.MaxTimestamp == nil {
+		return nil, nil
+	}
+
+	f := p.req.FilterOptions
+	lo := t.MinTimestamp.AsTime().UnixNano()
+	hi := t.MaxTimestamp.AsTime().UnixNano() + 1
+	if f.GetFrom() != nil {
+		lo = max(lo, f.GetFrom().AsTime().UnixNano())
+	}
+	if f.GetTo() != nil {
+		hi = min(hi, f.GetTo().AsTime().UnixNano())
+	}
+	if hi <= lo {
+		return nil, nil
+	}
+
+	parts := p.parts
+	if parts <= 0 {
+		parts = c.laneFor(methodStreamQuery, o).max
+	}
+	if p.minRowsPerPart > 0 {
+		parts = min(parts, max(1, int(t.RowCount/p.minRowsPerPart)))
+	}
+	parts = int(min(int64(parts), hi-lo))
+	if parts <= 1 {
+		return nil, nil
+	}
+
+	// buckets of an aggregated query must not straddle two parts
+	width := int64(p.req.AggregationOptions.GetTimeBucket()) * int64(time.Millisecond)
+	step := (hi - lo) / int64(parts)
+	var ranges []timeRange
+	from := lo
+	for i := 1; i <= parts; i++ {
+		to := hi
+		if i < parts {
+			to = lo + int64(i)*step
+			if width > 0 {
+				to -= ((to % width) + width) % width
+			}
+		}
+		if to > from {
+			ranges = append(ranges, timeRange{from: from, to: to})
+			from = to
+		}
+	}
+
+	if f.GetReverse() && !p.req.Head {
+		slices.Reverse(ranges)
+	}
+	return ranges, nil
+}
+
+// splitMerger hands the rows of the parts to the reader part by part. The
+// parts after the one being read buffer up to max bytes between them.
+//
+// Parts are started in order, up to window of them from the one being read,
+// and each only once the one before it has had a conn, so the part being
+// read never waits for a conn or in-flight slot taken by a later part. It
+// can still wait for one after losing its conn, or while another call holds
+// them all; the later parts then buffer past max so that they finish and
+// give theirs back.
+type splitMerger struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	parts    []splitPart
+	head     int
+	buffered int
+	max      int
+	stopped  bool
+
+	window  int
+	started int
+	run     func(m *splitMerger, i int)
+}
+
+type splitPart struct {
+	batches [][]*proto.Row
+	sizes   []int
+	// holds a conn, and has had one
+	holds, ready bool
+	done         bool
+	resp         *proto.QueryResponse
+	err          error
+}
+
+func newSplitMerger(parts, maxBytes, window int, run func(m *splitMerger, i int)) *splitMerger {
+	m := &splitMerger{parts: make([]splitPart, parts), max: maxBytes, window: window, run: run}
+	m.cond = sync.NewCond(&m.mu)
+	return m
+}
+
+func (m *splitMerger) start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.startNext()
+}
+
+// startNext starts the next part if it may run yet. The caller holds m.mu.
+func (m *splitMerger) startNext() {
+	i := m.started
+	if m.stopped || i >= len(m.parts) || i >= m.head+m.window || i > 0 && !m.parts[i-1].ready {
+		return
+	}
+	m.started++
+	m.run(m, i)
+}
+
+// hold records part i checking a conn out or giving it back.
+func (m *splitMerger) hold(i int, held bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := &m.parts[i]
+	p.holds = held
+	if held {
+		p.ready = true
+		m.startNext()
+	}
+	m.cond.Broadcast()
+}
+
+// headWaiting reports whether the part being read is waiting for a conn.
+// The caller holds m.mu.
+func (m *splitMerger) headWaiting() bool {
+	if m.head >= len(m.parts) {
+		return false
+	}
+	h := &m.parts[m.head]
+	return !h.holds && !h.done
+}
+
+// push queues rows of part i, waiting while the buffer is full unless i is
+// the part being read or that part is waiting for a conn.
+func (m *splitMerger) push(i int, rows []*proto.Row) error {
+	size := 0
+	for _, r := range rows {
+		size += r.SizeVT()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for !m.stopped && i != m.head && m.buffered > 0 && m.buffered+size > m.max && !m.headWaiting() {
+		m.cond.Wait()
+	}
+	if m.stopped {
+		return errStopped
+	}
+	p := &m.parts[i]
+	p.batches = append(p.batches, rows)
+	p.sizes = append(p.sizes, size)
+	m.buffered += size
+	m.cond.Broadcast()
+	return nil
+}
+
+func (m *splitMerger) finish(i int, resp *proto.QueryResponse, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := &m.parts[i]
+	p.done, p.resp, p.err = true, resp, err
+	p.holds, p.ready = false, true
+	m.startNext()
+	m.cond.Broadcast()
+}
+
+// next returns the next rows of part i, or its response once it has none
+// left, which makes i+1 the part being read.
+func (m *splitMerger) next(ctx context.Context, i int) ([]*proto.Row, *proto.QueryResponse, error) {
+	stop := context.AfterFunc(ctx, m.stop)
+	defer stop()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := &m.parts[i]
+	for len(p.batches) == 0 && !p.done && !m.stopped {
+		m.cond.Wait()
+	}
+
+	switch {
+	case len(p.batches) > 0:
+		rows := p.batches[0]
+		m.buffered -= p.sizes[0]
+		p.batches, p.sizes = p.batches[1:], p.sizes[1:]
+		m.cond.Broadcast()
+		return rows, nil, nil
+	case p.done && p.err != nil:
+		return nil, nil, p.err
+	case p.done:
+		m.head = i + 1
+		m.startNext()
+		m.cond.Broadcast()
+		return nil, p.resp, nil
+	}
+	return nil, nil, ctx.Err()
+}
+
+// stop wakes and fails every waiting push once the reader is gone.
+func (m *splitMerger) stop() {
+	m.mu.Lock()
+	m.stopped = true
+	m.mu.Unlock()
+	m.cond.Broadcast()
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+)
+
+// splitAll runs req as a SplitQuery in parts of small batches and returns
+// the rows delivered, failing if it takes over ten seconds.
+func splitAll(c *Client, req *proto.QueryRequest, p *SplitQueryParams, opts ...CallOption) ([]*proto.Row, *proto.QueryResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req.StreamOptions = &proto.StreamOptions{RowsPerChunk: Uint32(10)}
+	var rows []*proto.Row
+	p.WithRequest(req).WithMinRowsPerPart(0).WithOnRow(func(r *proto.Row) error {
+		rows = append(rows, r)
+		return nil
+	})
+	resp, err := c.SplitQuery(ctx, p, opts...)
+	return rows, resp, err
+}
+
+func mustSplit(t *testing.T, c *Client, req *proto.QueryRequest, p *SplitQueryParams, opts ...CallOption) ([]*proto.Row, *proto.QueryResponse) {
+	t.Helper()
+	rows, resp, err := splitAll(c, req, p, opts...)
+	if err != nil {
+		t.Fatalf("split query: %v", err)
+	}
+	return rows, resp
+}
+
+func TestSplitQueryOrder(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 4})
+	seed(t, c, "t", "", 200)
+
+	rows, resp := mustSplit(t, c, rangeQuery("t", 10, 190), NewSplitQueryParams().WithParts(4))
+	checkRows(t, rows, 10, 190, false)
+	if resp.Count != 180 || resp.TruncatedByLimit {
+		t.Fatalf("response %+v", resp)
+	}
+
+	reverse := true
+	req := rangeQuery("t", 10, 190)
+	req.FilterOptions.Reverse = &reverse
+	rows, _ = mustSplit(t, c, req, NewSplitQueryParams().WithParts(4))
+	checkRows(t, rows, 10, 190, true)
+
+	limit := int64(75)
+	req = rangeQuery("t", 0, 200)
+	req.FilterOptions.Limit = &limit
+	rows, resp = mustSplit(t, c, req, NewSplitQueryParams().WithParts(4))
+	checkRows(t, rows, 0, 75, false)
+	if !resp.TruncatedByLimit {
+		t.Fatal("limited query not truncated")
+	}
+}
+
+func TestSplitQueryHead(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 4})
+	seed(t, c, "t", "", 200)
+
+	// every part would apply the server's head limit of its own
+	req := rangeQuery("t", 0, 200)
+	req.Head = true
+	rows, resp := mustSplit(t, c, req, NewSplitQueryParams().WithParts(4))
+	checkRows(t, rows, 0, 1, false)
+	if resp.Count != 1 {
+		t.Fatalf("response %+v", resp)
+	}
+}
+
+func TestSplitQueryMaxInFlight(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 4, MaxInFlight: 1})
+	seed(t, c, "t", "", 200)
+
+	// a buffer smaller than a batch makes every part after the one being
+	// read wait to push its second batch
+	p := func() *SplitQueryParams { return NewSplitQueryParams().WithParts(4).WithMaxBufferBytes(500) }
+	rows, _ := mustSplit(t, c, rangeQuery("t", 0, 200), p())
+	checkRows(t, rows, 0, 200, false)
+
+	rows, _ = mustSplit(t, c, rangeQuery("t", 0, 200), p(), WithAffinity("k"))
+	checkRows(t, rows, 0, 200, false)
+}
+
+func TestSplitQueriesShareLane(t *testing.T) {
+	c := dialTest(t, newTestServer(t), Config{PoolSize: 2})
+	seed(t, c, "t", "", 200)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rows, _, err := splitAll(c, rangeQuery("t", 0, 200), NewSplitQueryParams().WithParts(2).WithMaxBufferBytes(500))
+			if err == nil && len(rows) != 200 {
+				err = fmt.Errorf("got %d rows, want 200", len(rows))
+			}
+			if err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitMergerSpillsWhileHeadWaits(t *testing.T) {
+	m := newSplitMerger(2, 1, 2, func(*splitMerger, int) {})
+	rows := []*proto.Row{{Data: rowData(0)}}
+
+	// part 0 hasn't got a conn yet, so part 1 must not wait for it
+	for range 3 {
+		if err := m.push(1, rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m.hold(0, true)
+	pushed := make(chan error, 1)
+	go func() { pushed <- m.push(1, rows) }()
+	select {
+	case err := <-pushed:
+		t.Fatalf("push past a full buffer returned %v while the head holds a conn", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	m.hold(0, false)
+	if err := <-pushed; err != nil {
+		t.Fatal(err)
+	}
+}
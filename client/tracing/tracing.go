@@ -0,0 +1,305 @@
+// Package tracing adds OpenTelemetry spans to client.Client calls and
+// sends the trace context to the server as drpc metadata.
+package tracing
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/nonhumantrades/flowdb-go/client"
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/nonhumantrades/flowdb-go/client/tracing"
+	service             = "flowdb.FlowDB"
+)
+
+const (
+	AttrTable             = attribute.Key("flowdb.table")
+	AttrPrefix            = attribute.Key("flowdb.prefix")
+	AttrRows              = attribute.Key("flowdb.rows")
+	AttrRequestBytes      = attribute.Key("flowdb.request_bytes")
+	AttrResponseBytes     = attribute.Key("flowdb.response_bytes")
+	AttrUncompressedBytes = attribute.Key("flowdb.uncompressed_bytes")
+	AttrCompressedBytes   = attribute.Key("flowdb.compressed_bytes")
+	AttrCompression       = attribute.Key("flowdb.compression")
+	AttrAttempt           = attribute.Key("flowdb.attempt")
+	AttrConn              = attribute.Key("flowdb.conn")
+	AttrMessages          = attribute.Key("flowdb.messages")
+	AttrCacheHit          = attribute.Key("flowdb.cache_hit")
+)
+
+type config struct {
+	provider    trace.TracerProvider
+	propagators propagation.TextMapPropagator
+}
+
+type Option func(*config)
+
+// WithTracerProvider sets the provider spans are created with (default =
+// otel.GetTracerProvider()).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.provider = tp }
+}
+
+// WithPropagators sets how the span context is written to the drpc
+// metadata (default = otel.GetTextMapPropagator()).
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) { c.propagators = p }
+}
+
+// Instrument appends the tracing interceptors to cfg, so they run inside
+// any interceptors added later.
+func Instrument(cfg *client.Config, opts ...Option) {
+	t := newTracer(opts)
+	cfg.CallInterceptors = append(cfg.CallInterceptors, t.call)
+	cfg.UnaryInterceptors = append(cfg.UnaryInterceptors, t.unary)
+	cfg.StreamInterceptors = append(cfg.StreamInterceptors, t.stream)
+}
+
+// Interceptors returns interceptors that start a client span for every
+// attempt of a call. Streaming spans end with the stream.
+func Interceptors(opts ...Option) (client.UnaryInterceptor, client.StreamInterceptor) {
+	t := newTracer(opts)
+	return t.unary, t.stream
+}
+
+// CallInterceptor returns an interceptor that starts a span for every call,
+// the parent of the spans of its attempts. Calls answered from the cache
+// get a span without attempts.
+func CallInterceptor(opts ...Option) client.CallInterceptor {
+	return newTracer(opts).call
+}
+
+func newTracer(opts []Option) *tracer {
+	cfg := &config{
+		provider:    otel.GetTracerProvider(),
+		propagators: otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &tracer{
+		tracer:      cfg.provider.Tracer(instrumentationName),
+		propagators: cfg.propagators,
+	}
+}
+
+type tracer struct {
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+}
+
+// callAttributes describes the call of info and its request.
+func callAttributes(info *client.CallInfo, req any) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "drpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", info.Method.Name()),
+	}
+	if info.Table != "" {
+		attrs = append(attrs, AttrTable.String(info.Table))
+	}
+	return append(attrs, requestAttributes(req)...)
+}
+
+func (t *tracer) call(ctx context.Context, info *client.CallInfo, req any, call client.CallInvoker) error {
+	ctx, span := t.tracer.Start(ctx, service+"/"+info.Method.Name(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(callAttributes(info, req)...),
+	)
+	defer span.End()
+
+	err := call(ctx)
+	span.SetAttributes(AttrCacheHit.Bool(info.CacheHit))
+	if err != nil {
+		setError(span, err)
+	}
+	return err
+}
+
+// start opens the span of an attempt and returns ctx carrying it, both in
+// process and in the drpc metadata.
+func (t *tracer) start(ctx context.Context, info *client.CallInfo, req any) (context.Context, trace.Span) {
+	attrs := append(callAttributes(info, req),
+		AttrAttempt.Int(info.Attempt),
+		AttrConn.Int(info.Conn),
+	)
+	if info.Endpoint != "" {
+		attrs = append(attrs, attribute.String("server.address", info.Endpoint))
+	}
+
+	ctx, span := t.tracer.Start(ctx, service+"/"+info.Method.Name(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	carrier := propagation.MapCarrier{}
+	t.propagators.Inject(ctx, carrier)
+	if len(carrier) > 0 {
+		ctx = client.WithMetadata(ctx, carrier)
+	}
+	return ctx, span
+}
+
+func (t *tracer) unary(ctx context.Context, info *client.CallInfo, req any, invoke client.UnaryInvoker) (any, error) {
+	ctx, span := t.start(ctx, info, req)
+	defer span.End()
+
+	resp, err := invoke(ctx)
+	if err != nil {
+		setError(span, err)
+		return resp, err
+	}
+	span.SetAttributes(responseAttributes(resp)...)
+	return resp, nil
+}
+
+func (t *tracer) stream(ctx context.Context, info *client.CallInfo, req any, open client.Streamer) (client.ClientStream, error) {
+	ctx, span := t.start(ctx, info, req)
+
+	s, err := open(ctx)
+	if err != nil {
+		setError(span, err)
+		span.End()
+		return nil, err
+	}
+
+	ts := &tracedStream{ClientStream: s, span: span}
+	// the call's context is cancelled once it stops reading, so this only
+	// ends streams abandoned before EOF
+	stop := context.AfterFunc(ctx, func() { ts.end(context.Cause(ctx)) })
+	ts.mu.Lock()
+	ts.stop = stop
+	ts.mu.Unlock()
+	return ts, nil
+}
+
+type tracedStream struct {
+	client.ClientStream
+	span trace.Span
+
+	mu       sync.Mutex
+	stop     func() bool
+	messages int
+	bytes    int
+	done     bool
+}
+
+func (s *tracedStream) Recv() (any, error) {
+	msg, err := s.ClientStream.Recv()
+	if err == io.EOF {
+		s.end(nil)
+		return msg, err
+	}
+	if err != nil {
+		s.end(err)
+		return msg, err
+	}
+
+	s.mu.Lock()
+	s.messages++
+	s.bytes += sizeOf(msg)
+	s.mu.Unlock()
+	if c, ok := msg.(*proto.StreamQueryChunk); ok {
+		s.span.SetAttributes(responseAttributes(c)...)
+	}
+	return msg, nil
+}
+
+// end finishes the span once, on the last Recv or when the call's context
+// is done.
+func (s *tracedStream) end(err error) {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return
+	}
+	s.done = true
+	messages, bytes, stop := s.messages, s.bytes, s.stop
+	s.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+	if err != nil {
+		setError(s.span, err)
+	}
+	s.span.SetAttributes(AttrMessages.Int(messages), AttrResponseBytes.Int(bytes))
+	s.span.End()
+}
+
+func setError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func sizeOf(msg any) int {
+	if m, ok := msg.(interface{ SizeVT() int }); ok {
+		return m.SizeVT()
+	}
+	return 0
+}
+
+func requestAttributes(req any) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{AttrRequestBytes.Int(sizeOf(req))}
+
+	switch r := req.(type) {
+	case *proto.InsertRequest:
+		attrs = append(attrs,
+			AttrPrefix.String(r.Prefix),
+			AttrRows.Int(len(r.Rows)),
+		)
+	case *proto.QueryRequest:
+		attrs = append(attrs,
+			AttrPrefix.String(r.Prefix),
+			AttrCompression.String(r.Compression.String()),
+		)
+	case *proto.DeleteRequest:
+		attrs = append(attrs, AttrPrefix.String(r.Prefix))
+	case *proto.BackupRequest:
+		attrs = append(attrs, AttrCompression.String(r.Compression.String()))
+	}
+	return attrs
+}
+
+// responseAttributes describes a unary response or a stream message.
+func responseAttributes(resp any) []attribute.KeyValue {
+	switch r := resp.(type) {
+	case *proto.QueryResponse:
+		return []attribute.KeyValue{
+			AttrResponseBytes.Int(sizeOf(r)),
+			AttrRows.Int64(int64(r.Count)),
+			AttrCompression.String(r.Compression.String()),
+			AttrUncompressedBytes.Int64(int64(r.UncompressedBytes)),
+			AttrCompressedBytes.Int64(int64(r.CompressedBytes)),
+		}
+	case *proto.DeleteResponse:
+		return []attribute.KeyValue{
+			AttrResponseBytes.Int(sizeOf(r)),
+			AttrRows.Int64(int64(r.DeletedRows)),
+		}
+	case *proto.StreamQueryChunk:
+		switch c := r.Chunk.(type) {
+		case *proto.StreamQueryChunk_Header:
+			return []attribute.KeyValue{AttrCompression.String(c.Header.Compression.String())}
+		case *proto.StreamQueryChunk_Footer:
+			return []attribute.KeyValue{
+				AttrRows.Int64(int64(c.Footer.Count)),
+				AttrUncompressedBytes.Int64(int64(c.Footer.UncompressedBytes)),
+				AttrCompressedBytes.Int64(int64(c.Footer.CompressedBytes)),
+			}
+		}
+		return nil
+	case nil:
+		return nil
+	}
+	return []attribute.KeyValue{AttrResponseBytes.Int(sizeOf(resp))}
+}
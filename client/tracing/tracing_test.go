@@ -0,0 +1,198 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/client"
+	"github.com/nonhumantrades/flowdb-go/memserver"
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// dial instruments cfg with spans recorded by the returned exporter, then
+// dials a memserver seeded with table "t". inner interceptors run inside
+// the tracing ones.
+func dial(t *testing.T, cfg client.Config, inner ...client.UnaryInterceptor) (*client.Client, *tracetest.InMemoryExporter) {
+	t.Helper()
+	s := memserver.New()
+	t.Cleanup(func() { _ = s.Close() })
+
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	Instrument(&cfg, WithTracerProvider(tp))
+	cfg.UnaryInterceptors = append(cfg.UnaryInterceptors, inner...)
+	cfg.Address, cfg.Dialer = "memserver", s.Dial
+	cfg.KeepaliveInterval = -1
+
+	c, err := client.Dial(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	ctx := context.Background()
+	if _, err := c.CreateTable(ctx, "t"); err != nil {
+		t.Fatal(err)
+	}
+	rows := make([]*proto.Row, 10)
+	for i := range rows {
+		rows[i] = &proto.Row{Timestamp: timestamppb.New(time.Unix(int64(i), 0)), Data: []byte("row")}
+	}
+	if _, err := c.Insert(ctx, &proto.InsertRequest{TableName: "t", Rows: rows}); err != nil {
+		t.Fatal(err)
+	}
+	exp.Reset()
+	return c, exp
+}
+
+// split returns the call span of spans and its attempts, in order.
+func split(t *testing.T, spans tracetest.SpanStubs) (call tracetest.SpanStub, attempts []tracetest.SpanStub) {
+	t.Helper()
+	var calls []tracetest.SpanStub
+	for _, s := range spans {
+		switch s.SpanKind {
+		case trace.SpanKindInternal:
+			calls = append(calls, s)
+		case trace.SpanKindClient:
+			attempts = append(attempts, s)
+		}
+	}
+	if len(calls) != 1 {
+		t.Fatalf("%d call spans, want 1", len(calls))
+	}
+	call = calls[0]
+	for _, a := range attempts {
+		if a.Parent.SpanID() != call.SpanContext.SpanID() || a.SpanContext.TraceID() != call.SpanContext.TraceID() {
+			t.Fatalf("attempt span %q not a child of the call span", a.Name)
+		}
+	}
+	return call, attempts
+}
+
+func attr(s tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range s.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestCallSpanWithAttempts(t *testing.T) {
+	failFirst := func(ctx context.Context, info *client.CallInfo, req any, invoke client.UnaryInvoker) (any, error) {
+		if info.Method.Name() == "GetTable" && info.Attempt == 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return invoke(ctx)
+	}
+	c, exp := dial(t, client.Config{PoolSize: 2, Backoff: noWait{}}, failFirst)
+
+	if _, err := c.GetTable(context.Background(), "t"); err != nil {
+		t.Fatal(err)
+	}
+	call, attempts := split(t, exp.GetSpans())
+	if call.Name != "flowdb.FlowDB/GetTable" || call.Status.Code == codes.Error {
+		t.Fatalf("call span %q status %v", call.Name, call.Status)
+	}
+	if attr(call, AttrTable).AsString() != "t" || attr(call, AttrCacheHit).AsBool() {
+		t.Fatalf("call span attributes %v", call.Attributes)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("%d attempt spans, want 2", len(attempts))
+	}
+	for i, a := range attempts {
+		if n := attr(a, AttrAttempt).AsInt64(); n != int64(i+1) {
+			t.Fatalf("attempt span %d has attempt %d", i, n)
+		}
+	}
+	if attempts[0].Status.Code != codes.Error || attempts[1].Status.Code == codes.Error {
+		t.Fatalf("attempt statuses %v, %v", attempts[0].Status, attempts[1].Status)
+	}
+}
+
+func TestCallSpanError(t *testing.T) {
+	c, exp := dial(t, client.Config{})
+	if _, err := c.GetTable(context.Background(), "missing"); err == nil {
+		t.Fatal("no error")
+	}
+	call, attempts := split(t, exp.GetSpans())
+	if call.Status.Code != codes.Error || len(attempts) != 1 {
+		t.Fatalf("call status %v, %d attempts", call.Status, len(attempts))
+	}
+}
+
+func TestStreamSpans(t *testing.T) {
+	c, exp := dial(t, client.Config{})
+	req := &proto.QueryRequest{TableName: "t", StreamOptions: &proto.StreamOptions{RowsPerChunk: client.Uint32(4)}}
+	if _, err := c.StreamQuery(context.Background(), client.NewStreamQueryParams().WithRequest(req)); err != nil {
+		t.Fatal(err)
+	}
+	_, attempts := split(t, exp.GetSpans())
+	if len(attempts) != 1 {
+		t.Fatalf("%d attempt spans", len(attempts))
+	}
+	// header, three batches, footer
+	if n := attr(attempts[0], AttrMessages).AsInt64(); n != 5 {
+		t.Fatalf("stream span saw %d messages", n)
+	}
+	if n := attr(attempts[0], AttrRows).AsInt64(); n != 10 {
+		t.Fatalf("stream span counted %d rows", n)
+	}
+}
+
+func TestAbandonedStreamSpan(t *testing.T) {
+	c, exp := dial(t, client.Config{})
+	stop := errors.New("stop")
+	p := client.NewStreamQueryParams().WithRequest(&proto.QueryRequest{TableName: "t"}).WithOnRow(func(*proto.Row) error { return stop })
+	if _, err := c.StreamQuery(context.Background(), p); !errors.Is(err, stop) {
+		t.Fatalf("got %v, want the callback's error", err)
+	}
+
+	// the attempt span ends once the stream's context is cancelled
+	deadline := time.Now().Add(time.Second)
+	for len(exp.GetSpans()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	call, attempts := split(t, exp.GetSpans())
+	if len(attempts) != 1 {
+		t.Fatalf("%d attempt spans", len(attempts))
+	}
+	if attempts[0].Status.Code != codes.Error || call.Status.Code != codes.Error {
+		t.Fatalf("attempt status %v, call status %v", attempts[0].Status, call.Status)
+	}
+}
+
+func TestCacheHitSpan(t *testing.T) {
+	c, exp := dial(t, client.Config{Cache: client.CacheConfig{MaxBytes: 1 << 20, Horizon: time.Hour}})
+	req := &proto.QueryRequest{
+		TableName:     "t",
+		FilterOptions: &proto.FilterOptions{From: timestamppb.New(time.Unix(0, 0)), To: timestamppb.New(time.Unix(100, 0))},
+	}
+	for range 2 {
+		if _, err := c.Query(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spans := exp.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("%d spans, want a miss with its attempt and a hit", len(spans))
+	}
+	miss, _ := split(t, spans[:2])
+	hit, attempts := split(t, spans[2:])
+	if attr(miss, AttrCacheHit).AsBool() || !attr(hit, AttrCacheHit).AsBool() || len(attempts) != 0 {
+		t.Fatalf("miss %v, hit %v", miss.Attributes, hit.Attributes)
+	}
+}
+
+type noWait struct{}
+
+func (noWait) Next(int, time.Duration) (time.Duration, bool) { return 0, true }
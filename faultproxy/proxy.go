@@ -0,0 +1,352 @@
+package faultproxy
+
+/*
+ * TCP fault-injection proxy
+ *
+ * Sits between client.Dial and a server and misbehaves on demand: added
+ * latency, refused dials, dropped or reset connections and truncated frames.
+ */
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Direction selects the bytes the byte limits of Faults count and cut.
+type Direction int
+
+const (
+	ServerToClient Direction = iota
+	ClientToServer
+	BothDirections
+)
+
+type Faults struct {
+	// delay added before every forwarded read, in both directions
+	Latency time.Duration
+	// stop listening, so that dials fail with connection refused until the
+	// fault is cleared; open connections are kept
+	Refuse bool
+	// called with the 1-based index of every dial made through Dial;
+	// returning true fails it with connection refused without connecting
+	RefuseDial func(n int) bool
+	// direction the byte limits below apply to, each counted on its own
+	// (default = ServerToClient)
+	Direction Direction
+	// close the connection once this many bytes were forwarded (0 = never);
+	// the read that crosses the limit is forwarded whole
+	DropAfterBytes int64
+	// like DropAfterBytes, but the close is an RST instead of a FIN
+	ResetAfterBytes int64
+	// forward exactly this many bytes and then close, cutting the frame in
+	// flight (0 = never)
+	TruncateAfterBytes int64
+}
+
+type Stats struct {
+	Dials     uint64
+	Accepted  uint64
+	Refused   uint64
+	Dropped   uint64
+	Reset     uint64
+	Truncated uint64
+}
+
+type Proxy struct {
+	target string
+	addr   string
+
+	mu     sync.Mutex
+	lis    net.Listener // nil while refusing
+	faults Faults
+	conns  map[*link]struct{}
+	closed bool
+
+	dials     atomic.Uint64
+	accepted  atomic.Uint64
+	refused   atomic.Uint64
+	dropped   atomic.Uint64
+	reset     atomic.Uint64
+	truncated atomic.Uint64
+
+	wg sync.WaitGroup
+}
+
+// New starts a proxy on a loopback listener that forwards to target.
+func New(target string) (*Proxy, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Proxy{
+		target: target,
+		addr:   lis.Addr().String(),
+		lis:    lis,
+		conns:  make(map[*link]struct{}),
+	}
+
+	p.wg.Add(1)
+	go p.acceptLoop(lis)
+	return p, nil
+}
+
+// Addr is the host:port clients should dial.
+func (p *Proxy) Addr() string {
+	return p.addr
+}
+
+// Dial has the signature of client.Config.Dialer and connects to addr,
+// normally Addr, counting the dial for Faults.RefuseDial.
+func (p *Proxy) Dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	n := int(p.dials.Add(1))
+	if f := p.current(); f.RefuseDial != nil && f.RefuseDial(n) {
+		p.refused.Add(1)
+		return nil, &net.OpError{
+			Op:  "dial",
+			Net: "tcp",
+			Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+		}
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+// SetFaults replaces the active faults. Byte limits apply to connections
+// accepted afterwards; latency and Refuse apply immediately. It fails if
+// the listener can't be opened again on Addr after Refuse is cleared.
+func (p *Proxy) SetFaults(f Faults) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults = f
+	if p.closed {
+		return nil
+	}
+
+	switch {
+	case f.Refuse && p.lis != nil:
+		err := p.lis.Close()
+		p.lis = nil
+		return err
+	case !f.Refuse && p.lis == nil:
+		lis, err := net.Listen("tcp", p.addr)
+		if err != nil {
+			return err
+		}
+		p.lis = lis
+		p.wg.Add(1)
+		go p.acceptLoop(lis)
+	}
+	return nil
+}
+
+// Heal clears every fault.
+func (p *Proxy) Heal() error {
+	return p.SetFaults(Faults{})
+}
+
+func (p *Proxy) Stats() Stats {
+	return Stats{
+		Dials:     p.dials.Load(),
+		Accepted:  p.accepted.Load(),
+		Refused:   p.refused.Load(),
+		Dropped:   p.dropped.Load(),
+		Reset:     p.reset.Load(),
+		Truncated: p.truncated.Load(),
+	}
+}
+
+// DropConns closes every open connection with a FIN.
+func (p *Proxy) DropConns() {
+	for _, l := range p.snapshot() {
+		p.dropped.Add(1)
+		l.close(false)
+	}
+}
+
+// ResetConns closes every open connection with an RST.
+func (p *Proxy) ResetConns() {
+	for _, l := range p.snapshot() {
+		p.reset.Add(1)
+		l.close(true)
+	}
+}
+
+// Close stops accepting, closes every connection and waits for the
+// forwarding goroutines to exit.
+func (p *Proxy) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	var err error
+	if p.lis != nil {
+		err = p.lis.Close()
+		p.lis = nil
+	}
+	p.mu.Unlock()
+
+	for _, l := range p.snapshot() {
+		l.close(false)
+	}
+	p.wg.Wait()
+	return err
+}
+
+func (p *Proxy) snapshot() []*link {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]*link, 0, len(p.conns))
+	for l := range p.conns {
+		out = append(out, l)
+	}
+	return out
+}
+
+func (p *Proxy) current() Faults {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.faults
+}
+
+func (p *Proxy) acceptLoop(lis net.Listener) {
+	defer p.wg.Done()
+
+	for {
+		nc, err := lis.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+
+		p.accepted.Add(1)
+		p.wg.Add(1)
+		go p.serve(nc, p.current())
+	}
+}
+
+func (p *Proxy) serve(client net.Conn, f Faults) {
+	defer p.wg.Done()
+
+	server, err := net.Dial("tcp", p.target)
+	if err != nil {
+		_ = client.Close()
+		return
+	}
+
+	l := &link{client: client, server: server}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		l.close(false)
+		return
+	}
+	p.conns[l] = struct{}{}
+	p.mu.Unlock()
+
+	up, down := Faults{}, Faults{}
+	if f.Direction != ServerToClient {
+		up = f
+	}
+	if f.Direction != ClientToServer {
+		down = f
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		p.forward(l, server, client, up)
+	}()
+	go func() {
+		defer wg.Done()
+		p.forward(l, client, server, down)
+	}()
+	wg.Wait()
+
+	p.mu.Lock()
+	delete(p.conns, l)
+	p.mu.Unlock()
+}
+
+// forward copies src to dst, applying f, and closes the link when either
+// side fails.
+func (p *Proxy) forward(l *link, dst, src net.Conn, f Faults) {
+	defer l.close(false)
+
+	var sent int64
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if d := p.current().Latency; d > 0 {
+				time.Sleep(d)
+			}
+
+			chunk := buf[:n]
+			if f.TruncateAfterBytes > 0 && sent+int64(n) >= f.TruncateAfterBytes {
+				_, _ = dst.Write(chunk[:f.TruncateAfterBytes-sent])
+				p.truncated.Add(1)
+				return
+			}
+			if _, werr := dst.Write(chunk); werr != nil {
+				return
+			}
+			sent += int64(n)
+
+			if f.ResetAfterBytes > 0 && sent >= f.ResetAfterBytes {
+				p.reset.Add(1)
+				l.close(true)
+				return
+			}
+			if f.DropAfterBytes > 0 && sent >= f.DropAfterBytes {
+				p.dropped.Add(1)
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// link is one proxied client <-> server connection pair.
+type link struct {
+	client, server net.Conn
+	once           sync.Once
+}
+
+func (l *link) close(rst bool) {
+	l.once.Do(func() {
+		if rst {
+			for _, c := range []net.Conn{l.client, l.server} {
+				if tc, ok := c.(*net.TCPConn); ok {
+					_ = tc.SetLinger(0)
+				}
+			}
+		}
+		_ = l.client.Close()
+		_ = l.server.Close()
+	})
+}
+
+// FirstN refuses the first n dials.
+func FirstN(n int) func(int) bool {
+	return func(i int) bool { return i <= n }
+}
+
+// EveryNth refuses every nth dial.
+func EveryNth(n int) func(int) bool {
+	return func(i int) bool { return n > 0 && i%n == 0 }
+}
+
+// Always refuses every dial.
+func Always(int) bool { return true }
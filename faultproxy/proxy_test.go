@@ -0,0 +1,183 @@
+package faultproxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newEcho starts a server that writes back what it reads, and a proxy in
+// front of it. The server sends the bytes it read from every connection on
+// received once the connection ends.
+func newEcho(t *testing.T) (p *Proxy, received chan int64) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = lis.Close() })
+	received = make(chan int64, 16)
+	go func() {
+		for {
+			nc, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer nc.Close()
+				n, _ := io.Copy(nc, nc)
+				received <- n
+			}()
+		}
+	}()
+
+	p, err = New(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = p.Close() })
+	return p, received
+}
+
+// roundTrip sends msg through a fresh connection and returns what came
+// back before the connection ended.
+func roundTrip(t *testing.T, p *Proxy, msg []byte) []byte {
+	t.Helper()
+	nc, err := p.Dial(context.Background(), "tcp", p.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	_ = nc.SetDeadline(time.Now().Add(2 * time.Second))
+	if _, err := nc.Write(msg); err != nil {
+		return nil
+	}
+	got := make([]byte, 0, len(msg))
+	buf := make([]byte, len(msg))
+	for len(got) < len(msg) {
+		n, err := nc.Read(buf)
+		got = append(got, buf[:n]...)
+		if err != nil {
+			break
+		}
+	}
+	return got
+}
+
+func TestForward(t *testing.T) {
+	p, _ := newEcho(t)
+	msg := bytes.Repeat([]byte("x"), 1000)
+	if got := roundTrip(t, p, msg); !bytes.Equal(got, msg) {
+		t.Fatalf("got %d bytes back, want %d", len(got), len(msg))
+	}
+}
+
+func TestRefuse(t *testing.T) {
+	p, _ := newEcho(t)
+	if err := p.SetFaults(Faults{Refuse: true}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := net.Dial("tcp", p.Addr())
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("dial while refusing: got %v, want connection refused", err)
+	}
+
+	if err := p.Heal(); err != nil {
+		t.Fatal(err)
+	}
+	if got := roundTrip(t, p, []byte("ping")); string(got) != "ping" {
+		t.Fatalf("after heal: got %q", got)
+	}
+}
+
+func TestRefuseDial(t *testing.T) {
+	p, _ := newEcho(t)
+	_ = p.SetFaults(Faults{RefuseDial: FirstN(2)})
+	for i := 1; i <= 3; i++ {
+		nc, err := p.Dial(context.Background(), "tcp", p.Addr())
+		if i <= 2 {
+			if !errors.Is(err, syscall.ECONNREFUSED) {
+				t.Fatalf("dial %d: got %v, want connection refused", i, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("dial %d: %v", i, err)
+		}
+		_ = nc.Close()
+	}
+	if st := p.Stats(); st.Dials != 3 || st.Refused != 2 || st.Accepted != 1 {
+		t.Fatalf("stats %+v", st)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	msg := bytes.Repeat([]byte("x"), 1000)
+	for _, tc := range []struct {
+		dir Direction
+		// bytes the server reads, and the client gets back at most, as
+		// the echo of a truncated request races the close
+		sent, back int64
+	}{
+		{ServerToClient, 1000, 100},
+		{ClientToServer, 100, 0},
+		{BothDirections, 100, 0},
+	} {
+		p, received := newEcho(t)
+		_ = p.SetFaults(Faults{Direction: tc.dir, TruncateAfterBytes: 100})
+		got := roundTrip(t, p, msg)
+		if int64(len(got)) > tc.back || (tc.dir == ServerToClient && int64(len(got)) != tc.back) {
+			t.Fatalf("direction %d: got %d bytes back, want %d", tc.dir, len(got), tc.back)
+		}
+		if n := <-received; n != tc.sent {
+			t.Fatalf("direction %d: server read %d bytes, want %d", tc.dir, n, tc.sent)
+		}
+		if p.Stats().Truncated != 1 {
+			t.Fatalf("direction %d: stats %+v", tc.dir, p.Stats())
+		}
+	}
+}
+
+func TestDropAndReset(t *testing.T) {
+	msg := bytes.Repeat([]byte("x"), 100)
+	for _, f := range []Faults{
+		{Direction: ClientToServer, DropAfterBytes: 1},
+		{Direction: ClientToServer, ResetAfterBytes: 1},
+	} {
+		p, _ := newEcho(t)
+		_ = p.SetFaults(f)
+		nc, err := p.Dial(context.Background(), "tcp", p.Addr())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = nc.SetDeadline(time.Now().Add(2 * time.Second))
+		_, _ = nc.Write(msg)
+		// the link is closed after the first write, whatever the echo sent
+		_, err = io.ReadAll(nc)
+		var ne net.Error
+		if errors.As(err, &ne) && ne.Timeout() {
+			t.Fatalf("%+v: connection left open", f)
+		}
+		_ = nc.Close()
+		st := p.Stats()
+		if st.Dropped+st.Reset != 1 {
+			t.Fatalf("%+v: stats %+v", f, st)
+		}
+	}
+}
+
+func TestLatency(t *testing.T) {
+	p, _ := newEcho(t)
+	_ = p.SetFaults(Faults{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	roundTrip(t, p, []byte("ping"))
+	// one delay each way
+	if d := time.Since(start); d < 100*time.Millisecond {
+		t.Fatalf("round trip took %v", d)
+	}
+}
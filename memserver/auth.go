@@ -0,0 +1,108 @@
+package memserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcmetadata"
+)
+
+// metadata key calls carry their token in
+const AuthorizationKey = "authorization"
+
+// grants on this table name cover every table
+const AllTables = "*"
+
+// AddCredential lets calls authenticated by token act as role. token is the
+// bearer token or API key without its scheme. Until the first credential is
+// added the server accepts every call.
+func (s *Server) AddCredential(token string, role *proto.Role) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.creds == nil {
+		s.creds = make(map[string]*proto.Role)
+	}
+	s.creds[token] = role
+}
+
+// RemoveCredential revokes token. Calls made with it fail as
+// unauthenticated afterwards. Removing the last credential turns auth off
+// again, so the server accepts every call.
+func (s *Server) RemoveCredential(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.creds, token)
+	if len(s.creds) == 0 {
+		s.creds = nil
+	}
+}
+
+// role returns the role of the call in ctx, or nil if auth is disabled.
+func (s *Server) role(ctx context.Context) (*proto.Role, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.creds == nil {
+		return nil, nil
+	}
+
+	md, _ := drpcmetadata.Get(ctx)
+	scheme, token, _ := strings.Cut(md[AuthorizationKey], " ")
+	if token == "" || (scheme != "Bearer" && scheme != "ApiKey") {
+		return nil, unauthenticated("missing bearer token or api key")
+	}
+	role, ok := s.creds[token]
+	if !ok {
+		return nil, unauthenticated("unknown token")
+	}
+	return role, nil
+}
+
+// authorize fails unless the call in ctx holds every perm on table.
+func (s *Server) authorize(ctx context.Context, method, table string, perms ...proto.Permission) error {
+	role, err := s.role(ctx)
+	if err != nil || role == nil {
+		return err
+	}
+	for _, p := range perms {
+		if !allowed(role, table, p) {
+			return drpcerr.WithCode(
+				fmt.Errorf("%s: role %q lacks %s on %q", method, role.Name, permName(p), table),
+				uint64(proto.ErrorCode_ErrorPermissionDenied),
+			)
+		}
+	}
+	return nil
+}
+
+func unauthenticated(msg string) error {
+	return drpcerr.WithCode(errors.New(msg), uint64(proto.ErrorCode_ErrorUnauthenticated))
+}
+
+// allowed reports whether role holds perm on table. Admin implies read and
+// write, and a grant on AllTables covers every table.
+func allowed(role *proto.Role, table string, perm proto.Permission) bool {
+	for _, g := range role.Grants {
+		if g.Table != table && g.Table != AllTables {
+			continue
+		}
+		for _, p := range g.Permissions {
+			if p == perm {
+				return true
+			}
+			if p == proto.Permission_PermissionAdmin &&
+				(perm == proto.Permission_PermissionRead || perm == proto.Permission_PermissionWrite) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func permName(p proto.Permission) string {
+	return strings.ToLower(strings.TrimPrefix(p.String(), "Permission"))
+}
@@ -0,0 +1,127 @@
+package memserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"storj.io/drpc/drpcerr"
+	"storj.io/drpc/drpcmetadata"
+)
+
+// as returns a context authenticated by "Bearer token".
+func as(token string) context.Context {
+	return drpcmetadata.Add(context.Background(), AuthorizationKey, "Bearer "+token)
+}
+
+func grant(table string, perms ...proto.Permission) *proto.TableGrant {
+	return &proto.TableGrant{Table: table, Permissions: perms}
+}
+
+func checkCode(t *testing.T, what string, err error, want proto.ErrorCode) {
+	t.Helper()
+	if want == proto.ErrorCode_ErrorNone {
+		if err != nil {
+			t.Fatalf("%s: %v", what, err)
+		}
+		return
+	}
+	if got := proto.ErrorCode(drpcerr.Code(err)); got != want {
+		t.Fatalf("%s: got %v (%v), want %v", what, got, err, want)
+	}
+}
+
+func TestAuthRoles(t *testing.T) {
+	s := New()
+	defer s.Close()
+	for _, name := range []string{"a", "b"} {
+		if _, err := s.CreateTable(context.Background(), &proto.CreateTableRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.AddCredential("writer", &proto.Role{Name: "writer", Grants: []*proto.TableGrant{grant("a", proto.Permission_PermissionWrite)}})
+	s.AddCredential("owner", &proto.Role{Name: "owner", Grants: []*proto.TableGrant{grant("a", proto.Permission_PermissionAdmin)}})
+	s.AddCredential("reader", &proto.Role{Name: "reader", Grants: []*proto.TableGrant{grant(AllTables, proto.Permission_PermissionRead)}})
+
+	rows := []*proto.Row{{Timestamp: timestamppb.New(time.Unix(1, 0)), Data: []byte("x")}}
+	insert := func(ctx context.Context, table string) error {
+		_, err := s.Insert(ctx, &proto.InsertRequest{TableName: table, Rows: rows})
+		return err
+	}
+	query := func(ctx context.Context, table string) error {
+		_, err := s.Query(ctx, &proto.QueryRequest{TableName: table})
+		return err
+	}
+	drop := func(ctx context.Context, table string) error {
+		_, err := s.DropTable(ctx, &proto.DropTableRequest{Name: table})
+		return err
+	}
+
+	none, denied := proto.ErrorCode_ErrorNone, proto.ErrorCode_ErrorPermissionDenied
+	for _, tc := range []struct {
+		token, table string
+		call         func(context.Context, string) error
+		want         proto.ErrorCode
+	}{
+		{"writer", "a", insert, none},
+		{"writer", "b", insert, denied},
+		{"writer", "a", query, denied},
+		// admin implies read and write on its table only
+		{"owner", "a", insert, none},
+		{"owner", "a", query, none},
+		{"owner", "b", query, denied},
+		{"reader", "a", query, none},
+		{"reader", "b", query, none},
+		{"reader", "b", insert, denied},
+		{"reader", "b", drop, denied},
+		{"owner", "a", drop, none},
+	} {
+		checkCode(t, tc.token+" on "+tc.table, tc.call(as(tc.token), tc.table), tc.want)
+	}
+
+	// tables the role can't read aren't listed, and "a" was dropped above
+	resp, err := s.ListTables(as("writer"), &proto.Empty{})
+	checkCode(t, "list tables", err, none)
+	if len(resp.Tables) != 0 {
+		t.Fatalf("writer lists %d tables", len(resp.Tables))
+	}
+	resp, err = s.ListTables(as("reader"), &proto.Empty{})
+	checkCode(t, "list tables", err, none)
+	if len(resp.Tables) != 1 || resp.Tables[0].Name != "b" {
+		t.Fatalf("reader lists %v", resp.Tables)
+	}
+}
+
+func TestAuthUnauthenticated(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	// without credentials every call is accepted
+	if _, err := s.ListTables(context.Background(), &proto.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.AddCredential("secret", &proto.Role{Name: "admin", Grants: []*proto.TableGrant{grant(AllTables, proto.Permission_PermissionAdmin)}})
+	unauth := proto.ErrorCode_ErrorUnauthenticated
+	for name, ctx := range map[string]context.Context{
+		"no token":      context.Background(),
+		"unknown token": as("wrong"),
+		"other scheme":  drpcmetadata.Add(context.Background(), AuthorizationKey, "Basic secret"),
+	} {
+		_, err := s.GetStats(ctx, &proto.Empty{})
+		checkCode(t, name, err, unauth)
+	}
+	apiKey := drpcmetadata.Add(context.Background(), AuthorizationKey, "ApiKey secret")
+	for _, ctx := range []context.Context{as("secret"), apiKey} {
+		_, err := s.GetStats(ctx, &proto.Empty{})
+		checkCode(t, "valid token", err, proto.ErrorCode_ErrorNone)
+	}
+
+	// the last credential removed turns auth off again
+	s.RemoveCredential("secret")
+	_, err := s.GetStats(context.Background(), &proto.Empty{})
+	checkCode(t, "auth off", err, proto.ErrorCode_ErrorNone)
+}
@@ -0,0 +1,337 @@
+package memserver
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// bytes per BackupChunk and per simulated S3 transfer step
+const BackupChunkSize = 1 << 20
+
+// A snapshot is a sequence of uvarint length-prefixed InsertRequest
+// messages, compressed as a whole. Every table is announced by a request
+// without rows so empty tables survive a restore.
+
+func (s *Server) tableNames() []string {
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// snapshot encodes every row written after since. The caller holds s.mu.
+func (s *Server) snapshot(since uint64) []byte {
+	var buf []byte
+	appendReq := func(req *proto.InsertRequest) {
+		b, _ := req.MarshalVT()
+		buf = binary.AppendUvarint(buf, uint64(len(b)))
+		buf = append(buf, b...)
+	}
+
+	for _, name := range s.tableNames() {
+		t := s.tables[name]
+		appendReq(&proto.InsertRequest{TableName: name})
+
+		prefixes := make([]string, 0, len(t.series))
+		for p := range t.series {
+			prefixes = append(prefixes, p)
+		}
+		slices.Sort(prefixes)
+
+		for _, p := range prefixes {
+			var rows []*proto.Row
+			for _, r := range t.series[p].rows {
+				if r.version <= since {
+					continue
+				}
+				rows = append(rows, &proto.Row{
+					Timestamp: timestamppb.New(time.Unix(0, r.ts)),
+					Data:      r.data,
+				})
+			}
+			if len(rows) > 0 {
+				appendReq(&proto.InsertRequest{TableName: name, Prefix: p, Rows: rows})
+			}
+		}
+	}
+	return buf
+}
+
+// restore applies an encoded snapshot. The caller holds s.mu.
+func (s *Server) restore(buf []byte) error {
+	version := s.nextVersion()
+	for len(buf) > 0 {
+		n, sz := binary.Uvarint(buf)
+		if sz <= 0 || uint64(len(buf)-sz) < n {
+			return errors.New("memserver: corrupt snapshot")
+		}
+		req := &proto.InsertRequest{}
+		if err := req.UnmarshalVT(buf[sz : sz+int(n)]); err != nil {
+			return err
+		}
+		buf = buf[sz+int(n):]
+
+		t, ok := s.tables[req.TableName]
+		if !ok {
+			t = newTable(req.TableName)
+			s.tables[req.TableName] = t
+		}
+		s.insertRows(t, req.Prefix, req.Rows, version)
+	}
+	return nil
+}
+
+func (s *Server) Backup(req *proto.BackupRequest, stream proto.DRPCFlowDB_BackupStream) error {
+	s.stats.backup.Add(1)
+	s.stats.total.Add(1)
+
+	if err := s.authorize(stream.Context(), "Backup", AllTables, proto.Permission_PermissionBackup); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	version := s.version
+	raw := s.snapshot(req.Version)
+	s.mu.RUnlock()
+
+	data, err := s.comp.Get(int32(req.Compression)).Compress(raw)
+	if err != nil {
+		return err
+	}
+
+	for len(data) > 0 {
+		n := min(len(data), BackupChunkSize)
+		if err := stream.Send(&proto.BackupChunk{Data: data[:n], Version: version}); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return stream.CloseSend()
+}
+
+// bucket is a simulated S3 bucket. Objects are stored uncompressed in the
+// order they were written.
+type bucket struct {
+	objects []*object
+}
+
+type object struct {
+	key     string
+	fullKey string // for incremental objects, the full backup they extend
+	version uint64
+	data    []byte
+}
+
+func (b *bucket) get(key string) *object {
+	for _, o := range b.objects {
+		if o.key == key {
+			return o
+		}
+	}
+	return nil
+}
+
+// chain returns the objects needed to restore key: its full backup followed
+// by every incremental up to and including key.
+func (b *bucket) chain(key string) ([]*object, error) {
+	o := b.get(key)
+	if o == nil {
+		return nil, fmt.Errorf("object %q not found", key)
+	}
+	if o.fullKey == "" {
+		return []*object{o}, nil
+	}
+
+	full := b.get(o.fullKey)
+	if full == nil {
+		return nil, fmt.Errorf("full backup %q not found", o.fullKey)
+	}
+	out := []*object{full}
+	for _, inc := range b.objects {
+		if inc.fullKey == o.fullKey && inc.version <= o.version {
+			out = append(out, inc)
+		}
+	}
+	return out, nil
+}
+
+// latestFull returns the most recent full backup in b, or nil.
+func (b *bucket) latestFull() *object {
+	for i := len(b.objects) - 1; i >= 0; i-- {
+		if b.objects[i].fullKey == "" {
+			return b.objects[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) BackupToS3(req *proto.S3BackupRequest, stream proto.DRPCFlowDB_BackupToS3Stream) error {
+	s.stats.backupToS3.Add(1)
+	s.stats.total.Add(1)
+	start := time.Now()
+
+	if err := s.authorize(stream.Context(), "BackupToS3", AllTables, proto.Permission_PermissionBackup); err != nil {
+		return err
+	}
+
+	name := req.S3Config.GetBucket()
+	if name == "" {
+		return errors.New("s3 bucket is required")
+	}
+
+	s.mu.Lock()
+	b, ok := s.buckets[name]
+	if !ok {
+		b = &bucket{}
+		s.buckets[name] = b
+	}
+
+	obj := &object{version: s.version}
+	if req.Incremental {
+		full := b.latestFull()
+		if req.FullKey != "" {
+			full = b.get(req.FullKey)
+		}
+		if full == nil {
+			s.mu.Unlock()
+			return errors.New("incremental backup requires an existing full backup")
+		}
+		since := full.version
+		for _, o := range b.objects {
+			if o.fullKey == full.key && o.version > since {
+				since = o.version
+			}
+		}
+		obj.fullKey = full.key
+		obj.key = fmt.Sprintf("flowdb-%d-incr", obj.version)
+		obj.data = s.snapshot(since)
+	} else {
+		obj.key = fmt.Sprintf("flowdb-%d-full", obj.version)
+		obj.data = s.snapshot(0)
+	}
+	if b.get(obj.key) == nil {
+		b.objects = append(b.objects, obj)
+	}
+	s.mu.Unlock()
+
+	err := stream.Send(&proto.S3BackupChunk{Chunk: &proto.S3BackupChunk_Header{
+		Header: &proto.S3BackupHeader{ObjectKey: obj.key},
+	}})
+	if err != nil {
+		return err
+	}
+
+	if err := sendProgress(len(obj.data), "upload", start, func(p *proto.BytesProgress) error {
+		return stream.Send(&proto.S3BackupChunk{Chunk: &proto.S3BackupChunk_Progress{Progress: p}})
+	}); err != nil {
+		return err
+	}
+
+	err = stream.Send(&proto.S3BackupChunk{Chunk: &proto.S3BackupChunk_Footer{
+		Footer: &proto.S3BackupFooter{
+			ObjectKey: obj.key,
+			Version:   obj.version,
+			Size:      uint64(len(obj.data)),
+			Duration:  uint64(time.Since(start)),
+		},
+	}})
+	if err != nil {
+		return err
+	}
+	return stream.CloseSend()
+}
+
+func (s *Server) RestoreFromS3(req *proto.S3RestoreRequest, stream proto.DRPCFlowDB_RestoreFromS3Stream) error {
+	s.stats.restoreFromS3.Add(1)
+	s.stats.total.Add(1)
+	start := time.Now()
+
+	if err := s.authorize(stream.Context(), "RestoreFromS3", AllTables, proto.Permission_PermissionAdmin, proto.Permission_PermissionBackup); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	b, ok := s.buckets[req.S3Config.GetBucket()]
+	if !ok || len(b.objects) == 0 {
+		s.mu.RUnlock()
+		return fmt.Errorf("no backups in bucket %q", req.S3Config.GetBucket())
+	}
+	key := req.ObjectKey
+	if key == "" {
+		key = b.objects[len(b.objects)-1].key
+	}
+	objs, err := b.chain(key)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, len(objs))
+	var total int
+	for i, o := range objs {
+		keys[i] = o.key
+		total += len(o.data)
+	}
+
+	err = stream.Send(&proto.S3RestoreChunk{Chunk: &proto.S3RestoreChunk_Header{
+		Header: &proto.S3RestoreHeader{Objects: keys},
+	}})
+	if err != nil {
+		return err
+	}
+
+	if err := sendProgress(total, "download", start, func(p *proto.BytesProgress) error {
+		return stream.Send(&proto.S3RestoreChunk{Chunk: &proto.S3RestoreChunk_Progress{Progress: p}})
+	}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.tables = make(map[string]*table)
+	for _, o := range objs {
+		if err = s.restore(o.data); err != nil {
+			break
+		}
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = stream.Send(&proto.S3RestoreChunk{Chunk: &proto.S3RestoreChunk_Footer{
+		Footer: &proto.S3RestoreFooter{
+			Size:     uint64(total),
+			Duration: uint64(time.Since(start)),
+		},
+	}})
+	if err != nil {
+		return err
+	}
+	return stream.CloseSend()
+}
+
+// sendProgress reports a simulated transfer of total bytes in
+// BackupChunkSize steps, always sending at least one update.
+func sendProgress(total int, kind string, start time.Time, send func(*proto.BytesProgress) error) error {
+	done := 0
+	for {
+		done = min(done+BackupChunkSize, total)
+		err := send(&proto.BytesProgress{
+			Type:           kind,
+			TotalBytes:     uint64(total),
+			CompletedBytes: uint64(done),
+			Duration:       uint64(time.Since(start)),
+		})
+		if err != nil || done == total {
+			return err
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package memserver
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ms(n int64) *timestamppb.Timestamp {
+	return timestamppb.New(time.UnixMilli(n))
+}
+
+// newTableServer returns a server holding table "t" with a row at every
+// millisecond in ts under prefix.
+func newTableServer(t *testing.T, prefix string, ts ...int64) *Server {
+	t.Helper()
+	s := New()
+	t.Cleanup(func() { _ = s.Close() })
+	if _, err := s.CreateTable(context.Background(), &proto.CreateTableRequest{Name: "t"}); err != nil {
+		t.Fatal(err)
+	}
+	insert(t, s, prefix, ts...)
+	return s
+}
+
+func insert(t *testing.T, s *Server, prefix string, ts ...int64) {
+	t.Helper()
+	rows := make([]*proto.Row, len(ts))
+	for i, n := range ts {
+		rows[i] = &proto.Row{Timestamp: ms(n), Data: []byte("x")}
+	}
+	if _, err := s.Insert(context.Background(), &proto.InsertRequest{TableName: "t", Prefix: prefix, Rows: rows}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// span returns the milliseconds n, n+1, ..., m-1.
+func span(n, m int64) []int64 {
+	var out []int64
+	for ; n < m; n++ {
+		out = append(out, n)
+	}
+	return out
+}
+
+// query runs req against table "t" and returns the rows' milliseconds.
+func query(t *testing.T, s *Server, req *proto.QueryRequest) ([]int64, *proto.QueryResponse) {
+	t.Helper()
+	req.TableName = "t"
+	resp, err := s.Query(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make([]int64, len(resp.Rows))
+	for i, r := range resp.Rows {
+		out[i] = r.Timestamp.AsTime().UnixMilli()
+	}
+	if resp.Count != uint64(len(out)) {
+		t.Fatalf("count %d for %d rows", resp.Count, len(out))
+	}
+	return out, resp
+}
+
+func limited(limit int64, reverse bool) *proto.FilterOptions {
+	return &proto.FilterOptions{Limit: &limit, Reverse: &reverse}
+}
+
+func TestQueryLimit(t *testing.T) {
+	s := newTableServer(t, "", span(0, DefaultLimit+1)...)
+
+	for _, tc := range []struct {
+		name      string
+		opts      *proto.FilterOptions
+		want      []int64
+		truncated bool
+	}{
+		{"no limit", limited(-1, false), span(0, DefaultLimit+1), false},
+		{"default limit", nil, span(0, DefaultLimit), true},
+		{"zero limit", limited(0, false), span(0, DefaultLimit), true},
+		{"limit", limited(3, false), []int64{0, 1, 2}, true},
+		{"reverse", limited(3, true), []int64{DefaultLimit, DefaultLimit - 1, DefaultLimit - 2}, true},
+		{"limit above rows", limited(DefaultLimit+5, false), span(0, DefaultLimit+1), false},
+		{"range", &proto.FilterOptions{From: ms(10), To: ms(13)}, []int64{10, 11, 12}, false},
+	} {
+		got, resp := query(t, s, &proto.QueryRequest{FilterOptions: tc.opts})
+		if !slices.Equal(got, tc.want) || resp.TruncatedByLimit != tc.truncated {
+			t.Errorf("%s: got %d rows (truncated %v), want %d (truncated %v)", tc.name, len(got), resp.TruncatedByLimit, len(tc.want), tc.truncated)
+		}
+	}
+}
+
+func TestQueryPrefixes(t *testing.T) {
+	s := newTableServer(t, "a", 1, 4, 5)
+	insert(t, s, "b", 2, 3, 6)
+
+	// rows of every prefix are merged in timestamp order
+	if got, _ := query(t, s, &proto.QueryRequest{}); !slices.Equal(got, []int64{1, 2, 3, 4, 5, 6}) {
+		t.Fatalf("all prefixes: %v", got)
+	}
+	if got, _ := query(t, s, &proto.QueryRequest{Prefix: "b", FilterOptions: limited(-1, true)}); !slices.Equal(got, []int64{6, 3, 2}) {
+		t.Fatalf("prefix b: %v", got)
+	}
+	if got, _ := query(t, s, &proto.QueryRequest{Prefix: "c"}); len(got) != 0 {
+		t.Fatalf("unknown prefix: %v", got)
+	}
+}
+
+func TestQueryHead(t *testing.T) {
+	s := newTableServer(t, "", span(0, 10)...)
+
+	for _, tc := range []struct {
+		name string
+		opts *proto.FilterOptions
+		want []int64
+	}{
+		{"default limit", nil, []int64{0}},
+		{"limit", limited(3, false), []int64{0, 1, 2}},
+		// reverse is ignored by head queries
+		{"reverse", limited(3, true), []int64{0, 1, 2}},
+		{"no limit", limited(-1, false), span(0, 10)},
+	} {
+		got, _ := query(t, s, &proto.QueryRequest{Head: true, FilterOptions: tc.opts})
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestQueryBuckets(t *testing.T) {
+	s := newTableServer(t, "", -1500, -200, 0, 500, 999, 1000, 2100, 2700)
+	bucket := func(width uint64, opts *proto.FilterOptions) []int64 {
+		got, _ := query(t, s, &proto.QueryRequest{
+			FilterOptions:      opts,
+			AggregationOptions: &proto.AggregationOptions{TimeBucket: &width},
+		})
+		return got
+	}
+
+	// the last row of every bucket is kept; negative timestamps round down
+	if got := bucket(1000, nil); !slices.Equal(got, []int64{-1500, -200, 999, 1000, 2700}) {
+		t.Fatalf("buckets: %v", got)
+	}
+	if got := bucket(1000, limited(-1, true)); !slices.Equal(got, []int64{2700, 1000, 999, -200, -1500}) {
+		t.Fatalf("reverse buckets: %v", got)
+	}
+	// the limit counts buckets, not rows
+	if got := bucket(1000, limited(2, false)); !slices.Equal(got, []int64{-1500, -200}) {
+		t.Fatalf("limited buckets: %v", got)
+	}
+	if got := bucket(0, limited(-1, false)); len(got) != 8 {
+		t.Fatalf("zero width kept %d rows", len(got))
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := newTableServer(t, "a", span(0, 10)...)
+	insert(t, s, "b", span(0, 10)...)
+
+	del := func(req *proto.DeleteRequest) uint64 {
+		t.Helper()
+		req.TableName = "t"
+		resp, err := s.Delete(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.DeletedRows
+	}
+	rows := func(prefix string) []int64 {
+		t.Helper()
+		got, _ := query(t, s, &proto.QueryRequest{Prefix: prefix})
+		return got
+	}
+
+	// From is inclusive and To exclusive
+	if n := del(&proto.DeleteRequest{Prefix: "a", FilterOptions: &proto.FilterOptions{From: ms(2), To: ms(5)}}); n != 3 {
+		t.Fatalf("deleted %d rows from a range of 3", n)
+	}
+	if got := rows("a"); !slices.Equal(got, []int64{0, 1, 5, 6, 7, 8, 9}) {
+		t.Fatalf("a after range delete: %v", got)
+	}
+	if got := rows("b"); len(got) != 10 {
+		t.Fatalf("other prefix lost rows: %v", got)
+	}
+
+	// a limit deletes the latest rows when reversed, across prefixes
+	if n := del(&proto.DeleteRequest{FilterOptions: limited(2, true)}); n != 2 {
+		t.Fatalf("deleted %d rows with limit 2", n)
+	}
+	if got := rows(""); !slices.Equal(got, []int64{0, 0, 1, 1, 2, 3, 4, 5, 5, 6, 6, 7, 7, 8, 8}) {
+		t.Fatalf("after limited delete: %v", got)
+	}
+
+	// without a limit every row in range goes
+	if n := del(&proto.DeleteRequest{Prefix: "b"}); n != 9 {
+		t.Fatalf("deleted %d rows of b", n)
+	}
+	resp, err := s.GetTable(context.Background(), &proto.GetTableRequest{TableName: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tbl := resp.Table; tbl.RowCount != 6 || tbl.DataBytes != 6 {
+		t.Fatalf("table %+v", tbl)
+	}
+}
+
+func TestLastUpdated(t *testing.T) {
+	s := newTableServer(t, "", 1, 2)
+	lastUpdated := func() time.Time {
+		t.Helper()
+		resp, err := s.GetTable(context.Background(), &proto.GetTableRequest{TableName: "t"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Table.LastUpdated.AsTime()
+	}
+	tick := func() { time.Sleep(2 * time.Millisecond) }
+
+	prev := lastUpdated()
+	tick()
+	insert(t, s, "", 3)
+	if next := lastUpdated(); !next.After(prev) {
+		t.Fatalf("insert left last_updated at %v", next)
+	}
+
+	// queries and deletes that match nothing don't count as updates
+	prev = lastUpdated()
+	tick()
+	query(t, s, &proto.QueryRequest{})
+	if _, err := s.Delete(context.Background(), &proto.DeleteRequest{TableName: "t", Prefix: "none"}); err != nil {
+		t.Fatal(err)
+	}
+	if next := lastUpdated(); !next.Equal(prev) {
+		t.Fatalf("last_updated moved from %v to %v", prev, next)
+	}
+
+	if _, err := s.Delete(context.Background(), &proto.DeleteRequest{TableName: "t"}); err != nil {
+		t.Fatal(err)
+	}
+	if next := lastUpdated(); !next.After(prev) {
+		t.Fatalf("delete left last_updated at %v", next)
+	}
+}
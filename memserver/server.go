@@ -0,0 +1,634 @@
+package memserver
+
+/*
+ * In-memory FlowDB server
+ *
+ * Implements proto.DRPCFlowDBServer without any external storage so the
+ * client and CLI can be exercised end-to-end in tests.
+ */
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/pkg/compression"
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"storj.io/drpc/drpcmux"
+	"storj.io/drpc/drpcserver"
+)
+
+var ErrClosed = errors.New("memserver: server closed")
+
+// idempotency keys remembered for deduplicating replayed inserts
+const MaxIdempotencyKeys = 1 << 16
+
+type Server struct {
+	mu      sync.RWMutex
+	tables  map[string]*table
+	buckets map[string]*bucket
+	version uint64
+
+	// responses of recent inserts by idempotency key; appliedQ is oldest first
+	applied  map[string]*proto.InsertResponse
+	appliedQ []string
+
+	// roles by token; nil until AddCredential turns auth on
+	creds map[string]*proto.Role
+
+	comp      *compression.Compression
+	stats     stats
+	startedAt time.Time
+
+	srv    *drpcserver.Server
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type stats struct {
+	bytesWritten      atomic.Uint64
+	bytesDeleted      atomic.Uint64
+	datapointsQueried atomic.Uint64
+
+	createTable   atomic.Uint64
+	dropTable     atomic.Uint64
+	insert        atomic.Uint64
+	delete        atomic.Uint64
+	query         atomic.Uint64
+	streamQuery   atomic.Uint64
+	getTable      atomic.Uint64
+	listTables    atomic.Uint64
+	backup        atomic.Uint64
+	backupToS3    atomic.Uint64
+	restoreFromS3 atomic.Uint64
+	total         atomic.Uint64
+}
+
+func New() *Server {
+	comp, err := compression.NewCompressor()
+	if err != nil {
+		// only fails for an invalid zstd level, and the default is valid
+		panic(err)
+	}
+
+	s := &Server{
+		tables:    make(map[string]*table),
+		buckets:   make(map[string]*bucket),
+		applied:   make(map[string]*proto.InsertResponse),
+		comp:      comp,
+		startedAt: time.Now(),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	mux := drpcmux.New()
+	if err := proto.DRPCRegisterFlowDB(mux, s); err != nil {
+		panic(err)
+	}
+	s.srv = drpcserver.New(mux)
+	return s
+}
+
+// Serve serves lis until ctx is done or the server is closed.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	if s.ctx.Err() != nil {
+		return ErrClosed
+	}
+
+	ctx, cancel := mergeCancel(ctx, s.ctx)
+	defer cancel()
+
+	s.wg.Add(1)
+	defer s.wg.Done()
+	return s.srv.Serve(ctx, lis)
+}
+
+// Listen starts serving on a loopback TCP listener and returns its host:port.
+func (s *Server) Listen() (string, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	go func() { _ = s.Serve(s.ctx, lis) }()
+	return lis.Addr().String(), nil
+}
+
+// ServeConn serves a single already established connection until it closes.
+func (s *Server) ServeConn(ctx context.Context, nc net.Conn) error {
+	if s.ctx.Err() != nil {
+		_ = nc.Close()
+		return ErrClosed
+	}
+
+	ctx, cancel := mergeCancel(ctx, s.ctx)
+	defer cancel()
+
+	s.wg.Add(1)
+	defer s.wg.Done()
+	return s.srv.ServeOne(ctx, nc)
+}
+
+// Dial has the signature of client.Config.Dialer and connects over Pipe,
+// ignoring network and addr.
+func (s *Server) Dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	if s.ctx.Err() != nil {
+		return nil, ErrClosed
+	}
+	return s.Pipe(), nil
+}
+
+// Pipe returns the client end of an in-process net.Pipe served by s.
+func (s *Server) Pipe() net.Conn {
+	cli, srv := net.Pipe()
+	go func() { _ = s.ServeConn(s.ctx, srv) }()
+	return cli
+}
+
+// Close stops every listener and connection served by s.
+func (s *Server) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// mergeCancel returns a context derived from ctx that is also cancelled
+// when other is done.
+func mergeCancel(ctx, other context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(other, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+func (s *Server) nextVersion() uint64 {
+	s.version++
+	return s.version
+}
+
+func (s *Server) getTable(name string) (*table, error) {
+	t, ok := s.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("table %q not found", name)
+	}
+	return t, nil
+}
+
+func (s *Server) CreateTable(ctx context.Context, req *proto.CreateTableRequest) (*proto.CreateTableResponse, error) {
+	s.stats.createTable.Add(1)
+	s.stats.total.Add(1)
+
+	if err := s.authorize(ctx, "CreateTable", req.Name, proto.Permission_PermissionAdmin); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, errors.New("table name is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tables[req.Name]; ok {
+		return nil, fmt.Errorf("table %q already exists", req.Name)
+	}
+	t := newTable(req.Name)
+	s.tables[req.Name] = t
+	s.nextVersion()
+
+	return &proto.CreateTableResponse{Table: t.toProto()}, nil
+}
+
+func (s *Server) DropTable(ctx context.Context, req *proto.DropTableRequest) (*proto.DropTableResponse, error) {
+	s.stats.dropTable.Add(1)
+	s.stats.total.Add(1)
+
+	if err := s.authorize(ctx, "DropTable", req.Name, proto.Permission_PermissionAdmin); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.getTable(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.bytesDeleted.Add(t.dataBytes)
+	delete(s.tables, req.Name)
+	s.nextVersion()
+
+	return &proto.DropTableResponse{}, nil
+}
+
+func (s *Server) Insert(ctx context.Context, req *proto.InsertRequest) (*proto.InsertResponse, error) {
+	s.stats.insert.Add(1)
+	s.stats.total.Add(1)
+	start := time.Now()
+
+	if err := s.authorize(ctx, "Insert", req.TableName, proto.Permission_PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if resp, ok := s.applied[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return resp, nil
+	}
+
+	t, err := s.getTable(req.TableName)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range req.Rows {
+		if r.GetTimestamp() == nil {
+			return nil, errors.New("row timestamp is required")
+		}
+	}
+
+	s.insertRows(t, req.Prefix, req.Rows, s.nextVersion())
+	resp := &proto.InsertResponse{Duration: uint64(time.Since(start))}
+	s.remember(req.IdempotencyKey, resp)
+	return resp, nil
+}
+
+// remember records the response for an idempotency key, forgetting the
+// oldest key once MaxIdempotencyKeys are held. The caller holds s.mu.
+func (s *Server) remember(key string, resp *proto.InsertResponse) {
+	if key == "" {
+		return
+	}
+	if len(s.appliedQ) >= MaxIdempotencyKeys {
+		delete(s.applied, s.appliedQ[0])
+		s.appliedQ = s.appliedQ[1:]
+	}
+	s.applied[key] = resp
+	s.appliedQ = append(s.appliedQ, key)
+}
+
+func (s *Server) insertRows(t *table, prefix string, rows []*proto.Row, version uint64) {
+	if len(rows) == 0 {
+		return
+	}
+
+	ser, ok := t.series[prefix]
+	if !ok {
+		ser = &series{}
+		t.series[prefix] = ser
+	}
+
+	var written uint64
+	for _, r := range rows {
+		added, delta := ser.insert(row{
+			ts:      r.Timestamp.AsTime().UnixNano(),
+			data:    r.Data,
+			version: version,
+		})
+		if added {
+			t.rowCount++
+		}
+		t.dataBytes = uint64(int64(t.dataBytes) + delta)
+		written += uint64(len(r.Data))
+	}
+	t.updatedAt = time.Now()
+	s.stats.bytesWritten.Add(written)
+}
+
+func (s *Server) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	s.stats.delete.Add(1)
+	s.stats.total.Add(1)
+	start := time.Now()
+
+	if err := s.authorize(ctx, "Delete", req.TableName, proto.Permission_PermissionWrite); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.getTable(req.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(req.FilterOptions, false)
+	if req.FilterOptions.GetLimit() == 0 {
+		f.limit = -1
+	}
+
+	type hit struct {
+		ser *series
+		ts  int64
+	}
+	var hits []hit
+	for _, ser := range t.prefixes(req.Prefix) {
+		lo, hi := ser.span(f.from, f.to)
+		for _, r := range ser.rows[lo:hi] {
+			hits = append(hits, hit{ser: ser, ts: r.ts})
+		}
+	}
+	if f.limit >= 0 && int64(len(hits)) > f.limit {
+		sort.SliceStable(hits, func(i, j int) bool {
+			if f.reverse {
+				return hits[i].ts > hits[j].ts
+			}
+			return hits[i].ts < hits[j].ts
+		})
+		hits = hits[:f.limit]
+	}
+
+	doomed := make(map[*series]map[int64]struct{})
+	for _, h := range hits {
+		if doomed[h.ser] == nil {
+			doomed[h.ser] = make(map[int64]struct{})
+		}
+		doomed[h.ser][h.ts] = struct{}{}
+	}
+
+	var deleted, freed uint64
+	for ser, tss := range doomed {
+		kept := ser.rows[:0]
+		for _, r := range ser.rows {
+			if _, ok := tss[r.ts]; ok {
+				deleted++
+				freed += uint64(len(r.data))
+				continue
+			}
+			kept = append(kept, r)
+		}
+		ser.rows = kept
+	}
+
+	if deleted > 0 {
+		t.rowCount -= deleted
+		t.dataBytes -= freed
+		t.updatedAt = time.Now()
+		s.stats.bytesDeleted.Add(freed)
+		s.nextVersion()
+	}
+
+	return &proto.DeleteResponse{
+		Duration:    uint64(time.Since(start)),
+		DeletedRows: deleted,
+	}, nil
+}
+
+// result is the outcome of a query before it is encoded for the wire.
+type result struct {
+	rows      []row
+	truncated bool
+}
+
+func (s *Server) query(req *proto.QueryRequest) (*result, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, err := s.getTable(req.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(req.FilterOptions, req.Head)
+	rows := selectRows(t.prefixes(req.Prefix), f)
+	rows = bucketRows(rows, req.AggregationOptions.GetTimeBucket(), f.reverse)
+	rows, truncated := f.truncate(rows)
+
+	s.stats.datapointsQueried.Add(uint64(len(rows)))
+	return &result{rows: rows, truncated: truncated}, nil
+}
+
+// encodeRows converts rows to proto rows, compressing each row's data with
+// method, and returns the uncompressed and compressed payload sizes.
+func (s *Server) encodeRows(rows []row, method proto.CompressionMethod) ([]*proto.Row, uint64, uint64, error) {
+	comp := s.comp.Get(int32(method))
+	out := make([]*proto.Row, len(rows))
+
+	var raw, sent uint64
+	for i, r := range rows {
+		data, err := comp.Compress(r.data)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		raw += uint64(len(r.data))
+		sent += uint64(len(data))
+		out[i] = &proto.Row{
+			Timestamp: timestamppb.New(time.Unix(0, r.ts)),
+			Data:      data,
+		}
+	}
+	return out, raw, sent, nil
+}
+
+func (s *Server) Query(ctx context.Context, req *proto.QueryRequest) (*proto.QueryResponse, error) {
+	s.stats.query.Add(1)
+	s.stats.total.Add(1)
+	start := time.Now()
+
+	if err := s.authorize(ctx, "Query", req.TableName, proto.Permission_PermissionRead); err != nil {
+		return nil, err
+	}
+
+	res, err := s.query(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, raw, sent, err := s.encodeRows(res.rows, req.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.QueryResponse{
+		TableName:         req.TableName,
+		Prefix:            req.Prefix,
+		Duration:          uint64(time.Since(start)),
+		Count:             uint64(len(rows)),
+		UncompressedBytes: raw,
+		CompressedBytes:   sent,
+		TruncatedByLimit:  res.truncated,
+		Compression:       req.Compression,
+		Rows:              rows,
+	}, nil
+}
+
+func (s *Server) StreamQuery(req *proto.QueryRequest, stream proto.DRPCFlowDB_StreamQueryStream) error {
+	s.stats.streamQuery.Add(1)
+	s.stats.total.Add(1)
+	start := time.Now()
+
+	if err := s.authorize(stream.Context(), "StreamQuery", req.TableName, proto.Permission_PermissionRead); err != nil {
+		return err
+	}
+
+	res, err := s.query(req)
+	if err != nil {
+		return err
+	}
+
+	err = stream.Send(&proto.StreamQueryChunk{Chunk: &proto.StreamQueryChunk_Header{
+		Header: &proto.StreamQueryHeader{
+			TableName:   req.TableName,
+			Prefix:      req.Prefix,
+			Compression: req.Compression,
+		},
+	}})
+	if err != nil {
+		return err
+	}
+
+	rowsPerChunk := int(req.StreamOptions.GetRowsPerChunk())
+	if rowsPerChunk == 0 {
+		rowsPerChunk = DefaultRowsPerChunk
+	}
+	targetBytes := uint64(req.StreamOptions.GetTargetBytes())
+
+	var (
+		raw, sent uint64
+		index     uint32
+		batch     []*proto.Row
+		batchSize uint64
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := stream.Send(&proto.StreamQueryChunk{Chunk: &proto.StreamQueryChunk_Batch{
+			Batch: &proto.StreamQueryBatch{Index: index, Rows: batch},
+		}})
+		index++
+		batch = nil
+		batchSize = 0
+		return err
+	}
+
+	for i := range res.rows {
+		rows, r, c, err := s.encodeRows(res.rows[i:i+1], req.Compression)
+		if err != nil {
+			return err
+		}
+		raw += r
+		sent += c
+		batch = append(batch, rows[0])
+		batchSize += c
+
+		full := len(batch) >= rowsPerChunk
+		if targetBytes > 0 {
+			full = batchSize >= targetBytes
+		}
+		if full {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	err = stream.Send(&proto.StreamQueryChunk{Chunk: &proto.StreamQueryChunk_Footer{
+		Footer: &proto.StreamQueryFooter{
+			Duration:          uint64(time.Since(start)),
+			Count:             uint64(len(res.rows)),
+			UncompressedBytes: raw,
+			CompressedBytes:   sent,
+			TruncatedByLimit:  res.truncated,
+		},
+	}})
+	if err != nil {
+		return err
+	}
+	return stream.CloseSend()
+}
+
+func (s *Server) GetTable(ctx context.Context, req *proto.GetTableRequest) (*proto.GetTableResponse, error) {
+	s.stats.getTable.Add(1)
+	s.stats.total.Add(1)
+
+	if err := s.authorize(ctx, "GetTable", req.TableName, proto.Permission_PermissionRead); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, err := s.getTable(req.TableName)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetTableResponse{Table: t.toProto()}, nil
+}
+
+func (s *Server) ListTables(ctx context.Context, _ *proto.Empty) (*proto.ListTablesResponse, error) {
+	s.stats.listTables.Add(1)
+	s.stats.total.Add(1)
+
+	role, err := s.role(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tables := make([]*proto.Table, 0, len(s.tables))
+	for _, name := range s.tableNames() {
+		if role != nil && !allowed(role, name, proto.Permission_PermissionRead) {
+			continue
+		}
+		tables = append(tables, s.tables[name].toProto())
+	}
+	return &proto.ListTablesResponse{Tables: tables}, nil
+}
+
+func (s *Server) GetStats(ctx context.Context, _ *proto.Empty) (*proto.DBStats, error) {
+	s.stats.total.Add(1)
+
+	if _, err := s.role(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	var onDisk uint64
+	for _, t := range s.tables {
+		onDisk += t.dataBytes
+	}
+	s.mu.RUnlock()
+
+	st := &s.stats
+	return &proto.DBStats{
+		StartedAt:             timestamppb.New(s.startedAt),
+		UptimeSeconds:         uint64(time.Since(s.startedAt) / time.Second),
+		BytesWritten:          st.bytesWritten.Load(),
+		BytesDeleted:          st.bytesDeleted.Load(),
+		DatapointsQueried:     st.datapointsQueried.Load(),
+		CreateTableRequests:   st.createTable.Load(),
+		DropTableRequests:     st.dropTable.Load(),
+		InsertRequests:        st.insert.Load(),
+		DeleteRequests:        st.delete.Load(),
+		QueryRequests:         st.query.Load(),
+		StreamQueryRequests:   st.streamQuery.Load(),
+		GetTableRequests:      st.getTable.Load(),
+		ListTablesRequests:    st.listTables.Load(),
+		BackupRequests:        st.backup.Load(),
+		BackupToS3Requests:    st.backupToS3.Load(),
+		RestoreFromS3Requests: st.restoreFromS3.Load(),
+		TotalRequests:         st.total.Load(),
+		OnDiskBytes:           onDisk,
+	}, nil
+}
+
+// Ping is not counted in the request stats so keepalive probes don't skew
+// them, and needs no token.
+func (s *Server) Ping(ctx context.Context, _ *proto.Empty) (*proto.Empty, error) {
+	return &proto.Empty{}, nil
+}
@@ -0,0 +1,212 @@
+package memserver
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/nonhumantrades/flowdb-go/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// rows returned when FilterOptions.limit is unset or 0
+	DefaultLimit = 10_000
+	// rows returned by a head query when FilterOptions.limit is unset or 0
+	DefaultHeadLimit = 1
+	// rows per StreamQueryBatch when StreamOptions.rows_per_chunk is unset or 0
+	DefaultRowsPerChunk = 1_000
+)
+
+type row struct {
+	ts      int64 // unix nanos
+	data    []byte
+	version uint64 // server version that last wrote the row
+}
+
+// series holds the rows of one prefix, sorted by timestamp. A timestamp
+// appears at most once; inserting an existing timestamp overwrites it.
+type series struct {
+	rows []row
+}
+
+func (s *series) insert(r row) (added bool, delta int64) {
+	n := len(s.rows)
+	if n == 0 || s.rows[n-1].ts < r.ts {
+		s.rows = append(s.rows, r)
+		return true, int64(len(r.data))
+	}
+
+	i := sort.Search(n, func(i int) bool { return s.rows[i].ts >= r.ts })
+	if i < n && s.rows[i].ts == r.ts {
+		delta = int64(len(r.data)) - int64(len(s.rows[i].data))
+		s.rows[i] = r
+		return false, delta
+	}
+
+	s.rows = slices.Insert(s.rows, i, r)
+	return true, int64(len(r.data))
+}
+
+// span returns the index range [lo, hi) of rows with from <= ts < to.
+func (s *series) span(from, to int64) (int, int) {
+	lo := sort.Search(len(s.rows), func(i int) bool { return s.rows[i].ts >= from })
+	hi := sort.Search(len(s.rows), func(i int) bool { return s.rows[i].ts >= to })
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
+type table struct {
+	name      string
+	series    map[string]*series
+	rowCount  uint64
+	dataBytes uint64
+	updatedAt time.Time
+	createdAt time.Time
+}
+
+func newTable(name string) *table {
+	now := time.Now()
+	return &table{
+		name:      name,
+		series:    make(map[string]*series),
+		updatedAt: now,
+		createdAt: now,
+	}
+}
+
+func (t *table) bounds() (minTs, maxTs int64, ok bool) {
+	for _, s := range t.series {
+		if len(s.rows) == 0 {
+			continue
+		}
+		first, last := s.rows[0].ts, s.rows[len(s.rows)-1].ts
+		if !ok || first < minTs {
+			minTs = first
+		}
+		if !ok || last > maxTs {
+			maxTs = last
+		}
+		ok = true
+	}
+	return minTs, maxTs, ok
+}
+
+func (t *table) toProto() *proto.Table {
+	pt := &proto.Table{
+		Name:        t.name,
+		RowCount:    t.rowCount,
+		DataBytes:   t.dataBytes,
+		LastUpdated: timestamppb.New(t.updatedAt),
+		CreatedAt:   timestamppb.New(t.createdAt),
+	}
+	if minTs, maxTs, ok := t.bounds(); ok {
+		pt.MinTimestamp = timestamppb.New(time.Unix(0, minTs))
+		pt.MaxTimestamp = timestamppb.New(time.Unix(0, maxTs))
+	}
+	return pt
+}
+
+// prefixes returns the series selected by prefix. An empty prefix selects
+// every series in the table.
+func (t *table) prefixes(prefix string) []*series {
+	if prefix != "" {
+		if s, ok := t.series[prefix]; ok {
+			return []*series{s}
+		}
+		return nil
+	}
+	out := make([]*series, 0, len(t.series))
+	for _, s := range t.series {
+		out = append(out, s)
+	}
+	return out
+}
+
+type filter struct {
+	from, to int64
+	limit    int64 // < 0 = no limit
+	reverse  bool
+}
+
+func newFilter(opts *proto.FilterOptions, head bool) filter {
+	f := filter{
+		from:    minTimestamp,
+		to:      maxTimestamp,
+		limit:   opts.GetLimit(),
+		reverse: opts.GetReverse() && !head,
+	}
+	if opts.GetFrom() != nil {
+		f.from = opts.GetFrom().AsTime().UnixNano()
+	}
+	if opts.GetTo() != nil {
+		f.to = opts.GetTo().AsTime().UnixNano()
+	}
+	if f.limit == 0 {
+		f.limit = DefaultLimit
+		if head {
+			f.limit = DefaultHeadLimit
+		}
+	}
+	return f
+}
+
+const (
+	minTimestamp int64 = -1 << 63
+	maxTimestamp int64 = 1<<63 - 1
+)
+
+// selectRows returns the rows within f's range across all series in
+// timestamp order, descending when f.reverse. The limit is not applied.
+func selectRows(ss []*series, f filter) []row {
+	var out []row
+	for _, s := range ss {
+		lo, hi := s.span(f.from, f.to)
+		out = append(out, s.rows[lo:hi]...)
+	}
+	if len(ss) > 1 {
+		sort.SliceStable(out, func(i, j int) bool { return out[i].ts < out[j].ts })
+	}
+	if f.reverse {
+		slices.Reverse(out)
+	}
+	return out
+}
+
+// truncate applies f.limit to rows and reports whether rows were dropped.
+func (f filter) truncate(rows []row) ([]row, bool) {
+	if f.limit >= 0 && int64(len(rows)) > f.limit {
+		return rows[:f.limit], true
+	}
+	return rows, false
+}
+
+// bucketRows keeps the last row of every bucket of width ms milliseconds.
+func bucketRows(rows []row, ms uint64, reverse bool) []row {
+	if ms == 0 || len(rows) == 0 {
+		return rows
+	}
+	width := int64(ms) * int64(time.Millisecond)
+	bucket := func(ts int64) int64 {
+		b := ts / width
+		if ts < 0 && ts%width != 0 {
+			b--
+		}
+		return b
+	}
+
+	out := rows[:0:0]
+	for i, r := range rows {
+		last := i == len(rows)-1 || bucket(rows[i+1].ts) != bucket(r.ts)
+		if reverse {
+			// descending order: the latest row of a bucket comes first
+			last = i == 0 || bucket(rows[i-1].ts) != bucket(r.ts)
+		}
+		if last {
+			out = append(out, r)
+		}
+	}
+	return out
+}
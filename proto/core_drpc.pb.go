@@ -45,6 +45,7 @@ type DRPCFlowDBClient interface {
 	BackupToS3(ctx context.Context, in *S3BackupRequest) (DRPCFlowDB_BackupToS3Client, error)
 	RestoreFromS3(ctx context.Context, in *S3RestoreRequest) (DRPCFlowDB_RestoreFromS3Client, error)
 	GetStats(ctx context.Context, in *Empty) (*DBStats, error)
+	Ping(ctx context.Context, in *Empty) (*Empty, error)
 }
 
 type drpcFlowDBClient struct {
@@ -289,6 +290,15 @@ func (c *drpcFlowDBClient) GetStats(ctx context.Context, in *Empty) (*DBStats, e
 	return out, nil
 }
 
+func (c *drpcFlowDBClient) Ping(ctx context.Context, in *Empty) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/flowdb.FlowDB/Ping", drpcEncoding_File_core_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type DRPCFlowDBServer interface {
 	CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error)
 	DropTable(context.Context, *DropTableRequest) (*DropTableResponse, error)
@@ -302,6 +312,7 @@ type DRPCFlowDBServer interface {
 	BackupToS3(*S3BackupRequest, DRPCFlowDB_BackupToS3Stream) error
 	RestoreFromS3(*S3RestoreRequest, DRPCFlowDB_RestoreFromS3Stream) error
 	GetStats(context.Context, *Empty) (*DBStats, error)
+	Ping(context.Context, *Empty) (*Empty, error)
 }
 
 type DRPCFlowDBUnimplementedServer struct{}
@@ -354,9 +365,13 @@ func (s *DRPCFlowDBUnimplementedServer) GetStats(context.Context, *Empty) (*DBSt
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
+func (s *DRPCFlowDBUnimplementedServer) Ping(context.Context, *Empty) (*Empty, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
 type DRPCFlowDBDescription struct{}
 
-func (DRPCFlowDBDescription) NumMethods() int { return 12 }
+func (DRPCFlowDBDescription) NumMethods() int { return 13 }
 
 func (DRPCFlowDBDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
 	switch n {
@@ -468,6 +483,15 @@ func (DRPCFlowDBDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver
 						in1.(*Empty),
 					)
 			}, DRPCFlowDBServer.GetStats, true
+	case 12:
+		return "/flowdb.FlowDB/Ping", drpcEncoding_File_core_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCFlowDBServer).
+					Ping(
+						ctx,
+						in1.(*Empty),
+					)
+			}, DRPCFlowDBServer.Ping, true
 	default:
 		return "", nil, nil, nil, false
 	}
@@ -656,3 +680,19 @@ func (x *drpcFlowDB_GetStatsStream) SendAndClose(m *DBStats) error {
 	}
 	return x.CloseSend()
 }
+
+type DRPCFlowDB_PingStream interface {
+	drpc.Stream
+	SendAndClose(*Empty) error
+}
+
+type drpcFlowDB_PingStream struct {
+	drpc.Stream
+}
+
+func (x *drpcFlowDB_PingStream) SendAndClose(m *Empty) error {
+	if err := x.MsgSend(m, drpcEncoding_File_core_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}